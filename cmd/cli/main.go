@@ -11,6 +11,7 @@ import (
 	"github.com/PxyUp/fitter/pkg/http_client"
 	"github.com/PxyUp/fitter/pkg/logger"
 	"github.com/PxyUp/fitter/pkg/plugins/store"
+	"github.com/PxyUp/fitter/pkg/session"
 	"github.com/PxyUp/fitter/pkg/utils"
 	"github.com/atotto/clipboard"
 	"github.com/tidwall/gjson"
@@ -100,6 +101,7 @@ func main() {
 	}
 
 	cfg := getConfig(*filePath, *urlPath)
+	session.SetSessions(cfg.Sessions, log.With("component", "session"))
 	res, err := lib.Parse(cfg.Item, cfg.Limits, cfg.References, builder.PureString(gjson.Parse(*inputFlag).String()), log)
 	if err != nil {
 		fmt.Fprintln(os.Stderr, err.Error())
@@ -8,7 +8,9 @@ import (
 	"github.com/PxyUp/fitter/pkg/config"
 	"github.com/PxyUp/fitter/pkg/http_client"
 	"github.com/PxyUp/fitter/pkg/logger"
+	"github.com/PxyUp/fitter/pkg/notifier"
 	"github.com/PxyUp/fitter/pkg/plugins/store"
+	"github.com/PxyUp/fitter/pkg/processor"
 	"github.com/PxyUp/fitter/pkg/runtime"
 	"github.com/PxyUp/fitter/pkg/utils"
 	"gopkg.in/yaml.v3"
@@ -78,6 +80,7 @@ func main() {
 	verboseFlag := flag.Bool("verbose", false, "Provide logger")
 	pluginsFlag := flag.String("plugins", "", "Provide plugins folder")
 	logLevel := flag.String("log-level", "info", "Level for logger")
+	replayFlag := flag.Bool("replay", false, "Send notifications from dead letter and exit")
 	flag.Parse()
 
 	if *filePath == "" && *urlPath == "" {
@@ -106,11 +109,26 @@ func main() {
 		lg = logger.NewLogger(*logLevel)
 		utils.SetLogger(*logLevel)
 	}
+
+	if *replayFlag {
+		for _, item := range cfg.Items {
+			err := processor.ReplayDeadLetters(item, lg.With("component", "replay"))
+			if err != nil {
+				log.Fatalf("unable to replay dead letter for %s with error %s", item.Name, err.Error())
+				return
+			}
+		}
+		return
+	}
+
 	done := make(chan struct{})
 	go func() {
 		<-ctx.Done()
 		lg.Infof("Shutdown....")
-		time.Sleep(time.Second * 4)
+		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), time.Second*4)
+		defer shutdownCancel()
+		notifier.Shutdown(shutdownCtx)
+		<-shutdownCtx.Done()
 		close(done)
 	}()
 	runtime.New(ctx, cfg, lg.With("component", "runtime")).Start()
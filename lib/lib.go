@@ -1,12 +1,18 @@
 package lib
 
 import (
+	"context"
 	"github.com/PxyUp/fitter/pkg/builder"
 	"github.com/PxyUp/fitter/pkg/config"
 	"github.com/PxyUp/fitter/pkg/logger"
 	"github.com/PxyUp/fitter/pkg/parser"
 	"github.com/PxyUp/fitter/pkg/registry"
 	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	shutdownTimeout = time.Second * 4
 )
 
 func Parse(item *config.Item, limits *config.Limits, refMap config.RefMap, input builder.Interfacable, log logger.Logger) (*parser.ParseResult, error) {
@@ -20,5 +26,13 @@ func Parse(item *config.Item, limits *config.Limits, refMap config.RefMap, input
 	if log == nil {
 		log = logger.Null
 	}
-	return registry.FromItem(cfg, log).Get(name).Process(input)
+	itemProcessor := registry.FromItem(cfg, log).Get(name)
+	result, err := itemProcessor.Process(input)
+
+	// send batched, digest and async notifications of the item before result is returned
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer shutdownCancel()
+	itemProcessor.Shutdown(shutdownCtx)
+
+	return result, err
 }
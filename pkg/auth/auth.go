@@ -0,0 +1,165 @@
+package auth
+
+import (
+	"encoding/base64"
+	"errors"
+	"github.com/PxyUp/fitter/pkg/config"
+	"github.com/PxyUp/fitter/pkg/logger"
+	"github.com/PxyUp/fitter/pkg/utils"
+	"net/http"
+	"os"
+	"strings"
+)
+
+const (
+	authorizationHeader = "Authorization"
+	cookieHeader        = "Cookie"
+	bearerPrefix        = "Bearer "
+)
+
+var (
+	errEmptyToken = errors.New("empty auth token")
+)
+
+func format(value string) string {
+	return utils.Format(value, nil, nil, nil)
+}
+
+// Credentials return username and password for basic or digest auth
+func Credentials(cfg *config.AuthConfig) (string, string, bool) {
+	if cfg == nil {
+		return "", "", false
+	}
+
+	if cfg.Basic != nil {
+		return format(cfg.Basic.Username), format(cfg.Basic.Password), true
+	}
+
+	if cfg.Digest != nil {
+		return format(cfg.Digest.Username), format(cfg.Digest.Password), true
+	}
+
+	return "", "", false
+}
+
+// Headers return headers which must be sent with each request, basic auth included, digest is not
+func Headers(cfg *config.AuthConfig, logger logger.Logger) (map[string]string, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+
+	if cfg.Basic != nil {
+		username, password, _ := Credentials(cfg)
+		return map[string]string{
+			authorizationHeader: "Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+password)),
+		}, nil
+	}
+
+	if cfg.Bearer != nil {
+		token, err := bearerToken(cfg.Bearer)
+		if err != nil {
+			logger.Errorw("unable to get bearer token", "error", err.Error())
+			return nil, err
+		}
+		return map[string]string{
+			authorizationHeader: bearerPrefix + token,
+		}, nil
+	}
+
+	if cfg.OAuth2ClientCredentials != nil {
+		token, err := oauth2Token(cfg.OAuth2ClientCredentials, logger)
+		if err != nil {
+			logger.Errorw("unable to get oauth2 token", "token_url", cfg.OAuth2ClientCredentials.TokenURL, "error", err.Error())
+			return nil, err
+		}
+		return map[string]string{
+			authorizationHeader: token.tokenType + " " + token.value,
+		}, nil
+	}
+
+	if cfg.FormLogin != nil {
+		token, err := formLoginToken(cfg.FormLogin, logger)
+		if err != nil {
+			logger.Errorw("unable to login", "url", cfg.FormLogin.Url, "error", err.Error())
+			return nil, err
+		}
+		header, value := formLoginHeader(cfg.FormLogin, token.value)
+		return map[string]string{
+			header: value,
+		}, nil
+	}
+
+	return nil, nil
+}
+
+// Invalidate drop cached token of the config, next call of Headers fetch new one
+func Invalidate(cfg *config.AuthConfig) {
+	if cfg == nil {
+		return
+	}
+
+	if cfg.OAuth2ClientCredentials != nil {
+		tokens.invalidate(cacheKey(cfg.OAuth2ClientCredentials))
+	}
+
+	if cfg.FormLogin != nil {
+		tokens.invalidate(cacheKey(cfg.FormLogin))
+	}
+}
+
+func isRefreshable(cfg *config.AuthConfig) bool {
+	return cfg.OAuth2ClientCredentials != nil || cfg.FormLogin != nil
+}
+
+func bearerToken(cfg *config.BearerAuthConfig) (string, error) {
+	token := format(cfg.Token)
+	if cfg.TokenFile != "" {
+		content, err := os.ReadFile(cfg.TokenFile)
+		if err != nil {
+			return "", err
+		}
+		token = string(content)
+	}
+
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return "", errEmptyToken
+	}
+
+	return token, nil
+}
+
+func formLoginHeader(cfg *config.FormLoginAuthConfig, token string) (string, string) {
+	header := cfg.Header
+	if header == "" {
+		header = authorizationHeader
+		if cfg.TokenCookie != "" {
+			header = cookieHeader
+		}
+	}
+
+	if cfg.Prefix != nil {
+		return header, *cfg.Prefix + token
+	}
+
+	if strings.EqualFold(header, cookieHeader) && cfg.TokenCookie != "" {
+		return header, cfg.TokenCookie + "=" + token
+	}
+
+	if strings.EqualFold(header, authorizationHeader) {
+		return header, bearerPrefix + token
+	}
+
+	return header, token
+}
+
+// SetHeaders add auth headers to request, existing headers with same name are replaced
+func SetHeaders(req *http.Request, headers map[string]string) {
+	for k, v := range headers {
+		if strings.EqualFold(k, cookieHeader) && req.Header.Get(cookieHeader) != "" {
+			req.Header.Set(cookieHeader, req.Header.Get(cookieHeader)+"; "+v)
+			continue
+		}
+		req.Header.Set(k, v)
+	}
+}
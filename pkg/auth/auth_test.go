@@ -0,0 +1,221 @@
+package auth_test
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"github.com/PxyUp/fitter/pkg/auth"
+	"github.com/PxyUp/fitter/pkg/config"
+	"github.com/PxyUp/fitter/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func get(t *testing.T, cfg *config.AuthConfig, rawURL string) int {
+	parsed, err := url.Parse(rawURL)
+	require.NoError(t, err)
+
+	client := &http.Client{Transport: auth.Transport(cfg, parsed.Host, nil, logger.Null)}
+	resp, err := client.Post(rawURL, "text/plain", strings.NewReader("body"))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	return resp.StatusCode
+}
+
+func TestBasicAndBearer(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if username, password, ok := r.BasicAuth(); ok && username == "user" && password == "pass" {
+			return
+		}
+		if r.Header.Get("Authorization") == "Bearer from_env" {
+			return
+		}
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	require.NoError(t, os.Setenv("FITTER_TEST_TOKEN", "from_env"))
+	defer os.Unsetenv("FITTER_TEST_TOKEN")
+
+	assert.Equal(t, http.StatusOK, get(t, &config.AuthConfig{Basic: &config.BasicAuthConfig{Username: "user", Password: "pass"}}, server.URL))
+	assert.Equal(t, http.StatusUnauthorized, get(t, &config.AuthConfig{Basic: &config.BasicAuthConfig{Username: "user", Password: "wrong"}}, server.URL))
+	assert.Equal(t, http.StatusOK, get(t, &config.AuthConfig{Bearer: &config.BearerAuthConfig{Token: "{{{FromEnv=FITTER_TEST_TOKEN}}}"}}, server.URL))
+}
+
+func TestOAuth2ClientCredentials(t *testing.T) {
+	var issued atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			_ = r.ParseForm()
+			if r.Form.Get("client_id") != "id" || r.Form.Get("client_secret") != "secret" || r.Form.Get("scope") != "read write" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			_, _ = fmt.Fprintf(w, `{"access_token": "token_%d", "token_type": "bearer", "expires_in": 3600}`, issued.Add(1))
+			return
+		}
+		// first token is revoked on the server side
+		if r.Header.Get("Authorization") != "Bearer token_2" {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer server.Close()
+
+	cfg := &config.AuthConfig{
+		OAuth2ClientCredentials: &config.OAuth2ClientCredentialsAuthConfig{
+			TokenURL:     server.URL + "/token",
+			ClientID:     "id",
+			ClientSecret: "secret",
+			Scopes:       []string{"read", "write"},
+		},
+	}
+
+	assert.Equal(t, http.StatusOK, get(t, cfg, server.URL+"/data"))
+	assert.Equal(t, http.StatusOK, get(t, cfg, server.URL+"/data"))
+	assert.Equal(t, int32(2), issued.Load())
+}
+
+func TestFormLogin(t *testing.T) {
+	var logins atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/login" {
+			_ = r.ParseForm()
+			if r.Form.Get("login") != "admin" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			logins.Add(1)
+			http.SetCookie(w, &http.Cookie{Name: "sid", Value: "cookie_token"})
+			_, _ = w.Write([]byte(`{"data": {"token": "json_token"}}`))
+			return
+		}
+		if r.URL.Path == "/redirect_login" {
+			logins.Add(1)
+			http.SetCookie(w, &http.Cookie{Name: "sid", Value: "cookie_token", Path: "/"})
+			http.Redirect(w, r, "/welcome", http.StatusFound)
+			return
+		}
+		if r.URL.Path == "/welcome" {
+			if _, err := r.Cookie("sid"); err != nil {
+				w.WriteHeader(http.StatusForbidden)
+			}
+			return
+		}
+		if r.Header.Get("X-Token") == "json_token" {
+			return
+		}
+		if sid, err := r.Cookie("sid"); err == nil && sid.Value == "cookie_token" {
+			return
+		}
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	jsonCfg := &config.AuthConfig{
+		FormLogin: &config.FormLoginAuthConfig{
+			Url:       server.URL + "/login",
+			Form:      map[string]string{"login": "admin"},
+			TokenPath: "data.token",
+			Header:    "X-Token",
+		},
+	}
+	assert.Equal(t, http.StatusOK, get(t, jsonCfg, server.URL+"/data"))
+	assert.Equal(t, http.StatusOK, get(t, jsonCfg, server.URL+"/data"))
+	assert.Equal(t, int32(1), logins.Load())
+
+	cookieCfg := &config.AuthConfig{
+		FormLogin: &config.FormLoginAuthConfig{
+			Url:         server.URL + "/login",
+			Form:        map[string]string{"login": "admin"},
+			TokenCookie: "sid",
+		},
+	}
+	assert.Equal(t, http.StatusOK, get(t, cookieCfg, server.URL+"/data"))
+	assert.Equal(t, int32(2), logins.Load())
+
+	redirectCfg := &config.AuthConfig{
+		FormLogin: &config.FormLoginAuthConfig{
+			Url:         server.URL + "/redirect_login",
+			Form:        map[string]string{"login": "admin"},
+			TokenCookie: "sid",
+		},
+	}
+	assert.Equal(t, http.StatusOK, get(t, redirectCfg, server.URL+"/data"))
+	assert.Equal(t, int32(3), logins.Load())
+}
+
+func TestOAuth2ShortToken(t *testing.T) {
+	var issued atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			_, _ = fmt.Fprintf(w, `{"access_token": "token_%d", "expires_in": 10}`, issued.Add(1))
+		}
+	}))
+	defer server.Close()
+
+	cfg := &config.AuthConfig{
+		OAuth2ClientCredentials: &config.OAuth2ClientCredentialsAuthConfig{
+			TokenURL: server.URL + "/token",
+			ClientID: "short",
+		},
+	}
+
+	assert.Equal(t, http.StatusOK, get(t, cfg, server.URL+"/data"))
+	assert.Equal(t, http.StatusOK, get(t, cfg, server.URL+"/data"))
+	assert.Equal(t, int32(1), issued.Load())
+}
+
+func TestDigest(t *testing.T) {
+	paramRe := regexp.MustCompile(`(\w+)="?([^",]*)"?`)
+	hash := func(value string) string {
+		sum := md5.Sum([]byte(value))
+		return hex.EncodeToString(sum[:])
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		params := make(map[string]string)
+		for _, match := range paramRe.FindAllStringSubmatch(strings.TrimPrefix(r.Header.Get("Authorization"), "Digest "), -1) {
+			params[match[1]] = match[2]
+		}
+		ha1 := hash("user:fitter:pass")
+		ha2 := hash(r.Method + ":" + params["uri"])
+		expected := hash(ha1 + ":nonce:" + params["nc"] + ":" + params["cnonce"] + ":auth:" + ha2)
+		if params["response"] != expected || params["opaque"] != "opaque" {
+			w.Header().Set("WWW-Authenticate", `Digest realm="fitter", nonce="nonce", qop="auth,auth-int", opaque="opaque"`)
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer server.Close()
+
+	assert.Equal(t, http.StatusOK, get(t, &config.AuthConfig{Digest: &config.BasicAuthConfig{Username: "user", Password: "pass"}}, server.URL+"/data?q=1"))
+	assert.Equal(t, http.StatusUnauthorized, get(t, &config.AuthConfig{Digest: &config.BasicAuthConfig{Username: "user", Password: "wrong"}}, server.URL+"/data?q=1"))
+}
+
+func TestRedirectToOtherHost(t *testing.T) {
+	var leaked atomic.Value
+	leaked.Store("")
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		leaked.Store(r.Header.Get("Authorization"))
+	}))
+	defer other.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		http.Redirect(w, r, other.URL+"/landing", http.StatusFound)
+	}))
+	defer server.Close()
+
+	assert.Equal(t, http.StatusOK, get(t, &config.AuthConfig{Bearer: &config.BearerAuthConfig{Token: "secret"}}, server.URL))
+	assert.Equal(t, "", leaked.Load())
+}
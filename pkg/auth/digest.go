@@ -0,0 +1,120 @@
+package auth
+
+import (
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"strings"
+)
+
+var (
+	errUnsupportedDigest = errors.New("unsupported digest challenge")
+)
+
+func isDigestChallenge(challenge string) bool {
+	return len(challenge) > 7 && strings.EqualFold(challenge[:7], "digest ")
+}
+
+// parseChallenge parse params of the WWW-Authenticate header like realm="test", qop="auth,auth-int"
+func parseChallenge(challenge string) map[string]string {
+	params := make(map[string]string)
+	rest := strings.TrimSpace(challenge[len("digest "):])
+	for rest != "" {
+		eq := strings.IndexByte(rest, '=')
+		if eq < 0 {
+			break
+		}
+		key := strings.ToLower(strings.TrimSpace(rest[:eq]))
+		rest = strings.TrimSpace(rest[eq+1:])
+
+		var value string
+		if strings.HasPrefix(rest, `"`) {
+			end := strings.IndexByte(rest[1:], '"')
+			if end < 0 {
+				value, rest = rest[1:], ""
+			} else {
+				value, rest = rest[1:end+1], rest[end+2:]
+			}
+		} else {
+			end := strings.IndexByte(rest, ',')
+			if end < 0 {
+				value, rest = rest, ""
+			} else {
+				value, rest = rest[:end], rest[end:]
+			}
+		}
+		params[key] = strings.TrimSpace(value)
+		rest = strings.TrimLeft(rest, ", ")
+	}
+
+	return params
+}
+
+func hashHex(h func() hash.Hash, value string) string {
+	hasher := h()
+	hasher.Write([]byte(value))
+	return hex.EncodeToString(hasher.Sum(nil))
+}
+
+func digestAuthorization(challenge string, method string, uri string, username string, password string) (string, error) {
+	params := parseChallenge(challenge)
+
+	algorithm := params["algorithm"]
+	h := md5.New
+	switch strings.TrimSuffix(strings.ToUpper(algorithm), "-SESS") {
+	case "", "MD5":
+	case "SHA-256":
+		h = sha256.New
+	default:
+		return "", errUnsupportedDigest
+	}
+
+	qop := ""
+	if params["qop"] != "" {
+		for _, value := range strings.Split(params["qop"], ",") {
+			if strings.TrimSpace(value) == "auth" {
+				qop = "auth"
+			}
+		}
+		if qop == "" {
+			return "", errUnsupportedDigest
+		}
+	}
+
+	cnonceBytes := make([]byte, 8)
+	if _, err := rand.Read(cnonceBytes); err != nil {
+		return "", err
+	}
+	cnonce := hex.EncodeToString(cnonceBytes)
+	nc := "00000001"
+
+	ha1 := hashHex(h, username+":"+params["realm"]+":"+password)
+	if strings.HasSuffix(strings.ToUpper(algorithm), "-SESS") {
+		ha1 = hashHex(h, ha1+":"+params["nonce"]+":"+cnonce)
+	}
+	ha2 := hashHex(h, method+":"+uri)
+
+	var response string
+	if qop == "" {
+		response = hashHex(h, ha1+":"+params["nonce"]+":"+ha2)
+	} else {
+		response = hashHex(h, ha1+":"+params["nonce"]+":"+nc+":"+cnonce+":"+qop+":"+ha2)
+	}
+
+	authorization := fmt.Sprintf(`Digest username="%s", realm="%s", nonce="%s", uri="%s", response="%s"`, username, params["realm"], params["nonce"], uri, response)
+	if algorithm != "" {
+		authorization += ", algorithm=" + algorithm
+	}
+	if params["opaque"] != "" {
+		authorization += fmt.Sprintf(`, opaque="%s"`, params["opaque"])
+	}
+	if qop != "" {
+		authorization += fmt.Sprintf(`, qop=%s, nc=%s, cnonce="%s"`, qop, nc, cnonce)
+	}
+
+	return authorization, nil
+}
@@ -0,0 +1,246 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/PxyUp/fitter/pkg/config"
+	"github.com/PxyUp/fitter/pkg/http_client"
+	"github.com/PxyUp/fitter/pkg/logger"
+	"github.com/PxyUp/fitter/pkg/session"
+	"github.com/tidwall/gjson"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	expirySkew   = 30 * time.Second
+	tokenTimeout = 60 * time.Second
+)
+
+var (
+	tokens = &tokenCache{
+		entries: make(map[string]*tokenEntry),
+	}
+)
+
+type token struct {
+	value     string
+	tokenType string
+	expiresAt time.Time
+}
+
+func (t *token) valid() bool {
+	return t != nil && (t.expiresAt.IsZero() || time.Now().Before(t.expiresAt))
+}
+
+type tokenEntry struct {
+	mutex sync.Mutex
+	token *token
+}
+
+// tokenCache share tokens between connectors with same auth config
+type tokenCache struct {
+	mutex   sync.Mutex
+	entries map[string]*tokenEntry
+}
+
+func (c *tokenCache) entry(key string) *tokenEntry {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		entry = &tokenEntry{}
+		c.entries[key] = entry
+	}
+	return entry
+}
+
+func (c *tokenCache) get(key string, fetch func() (*token, error)) (*token, error) {
+	entry := c.entry(key)
+	entry.mutex.Lock()
+	defer entry.mutex.Unlock()
+
+	if entry.token.valid() {
+		return entry.token, nil
+	}
+
+	newToken, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	entry.token = newToken
+	return newToken, nil
+}
+
+func (c *tokenCache) invalidate(key string) {
+	entry := c.entry(key)
+	entry.mutex.Lock()
+	entry.token = nil
+	entry.mutex.Unlock()
+}
+
+func cacheKey(cfg any) string {
+	key, _ := json.Marshal(cfg)
+	return fmt.Sprintf("%T:%s", cfg, key)
+}
+
+func doRequest(client *http.Client, req *http.Request) (*http.Response, []byte, error) {
+	reqCtx, cancel := context.WithTimeout(context.Background(), tokenTimeout)
+	defer cancel()
+
+	resp, err := client.Do(req.WithContext(reqCtx))
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return nil, nil, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	return resp, body, nil
+}
+
+func oauth2Token(cfg *config.OAuth2ClientCredentialsAuthConfig, logger logger.Logger) (*token, error) {
+	return tokens.get(cacheKey(cfg), func() (*token, error) {
+		form := url.Values{}
+		form.Set("grant_type", "client_credentials")
+		form.Set("client_id", format(cfg.ClientID))
+		form.Set("client_secret", format(cfg.ClientSecret))
+		if len(cfg.Scopes) > 0 {
+			form.Set("scope", strings.Join(cfg.Scopes, " "))
+		}
+		for k, v := range cfg.Params {
+			form.Set(k, format(v))
+		}
+
+		req, err := http.NewRequest(http.MethodPost, format(cfg.TokenURL), strings.NewReader(form.Encode()))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set("Accept", "application/json")
+
+		logger.Infow("requesting oauth2 token", "token_url", cfg.TokenURL)
+		_, body, err := doRequest(http_client.GetDefaultClient(), req)
+		if err != nil {
+			return nil, err
+		}
+
+		result := gjson.ParseBytes(body)
+		newToken := &token{
+			value:     result.Get("access_token").String(),
+			tokenType: result.Get("token_type").String(),
+		}
+		if newToken.value == "" {
+			return nil, errEmptyToken
+		}
+		if newToken.tokenType == "" || strings.EqualFold(newToken.tokenType, "bearer") {
+			newToken.tokenType = "Bearer"
+		}
+		if expiresIn := result.Get("expires_in").Int(); expiresIn > 0 {
+			lifetime := time.Duration(expiresIn) * time.Second
+			// short living token is not expired right after fetching
+			newToken.expiresAt = time.Now().Add(lifetime - min(expirySkew, lifetime/2))
+		}
+
+		return newToken, nil
+	})
+}
+
+func formLoginToken(cfg *config.FormLoginAuthConfig, logger logger.Logger) (*token, error) {
+	return tokens.get(cacheKey(cfg), func() (*token, error) {
+		method := cfg.Method
+		if method == "" {
+			method = http.MethodPost
+		}
+
+		body := format(cfg.Body)
+		if len(cfg.Form) > 0 {
+			form := url.Values{}
+			for k, v := range cfg.Form {
+				form.Set(k, format(v))
+			}
+			body = form.Encode()
+		}
+
+		req, err := http.NewRequest(method, format(cfg.Url), bytes.NewBufferString(body))
+		if err != nil {
+			return nil, err
+		}
+		if len(cfg.Form) > 0 {
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		}
+		for k, v := range cfg.Headers {
+			req.Header.Set(k, format(v))
+		}
+
+		var jar http.CookieJar
+		if cfg.Session != "" {
+			jar = session.Jar(cfg.Session)
+		}
+		if jar == nil {
+			// cookies set on redirect of the login are sent to next location only with jar
+			jar, _ = cookiejar.New(nil)
+		}
+		recorder := &cookieRecorder{
+			CookieJar: jar,
+		}
+		client := http_client.GetDefaultClient()
+		client.Jar = recorder
+
+		logger.Infow("sending login request", "url", cfg.Url)
+		resp, respBody, err := doRequest(client, req)
+		if err != nil {
+			return nil, err
+		}
+
+		newToken := &token{}
+		switch {
+		case cfg.TokenPath != "":
+			newToken.value = gjson.GetBytes(respBody, cfg.TokenPath).String()
+		case cfg.TokenCookie != "":
+			for _, cookie := range recorder.cookies {
+				if cookie.Name == cfg.TokenCookie {
+					newToken.value = cookie.Value
+				}
+			}
+		case cfg.TokenHeader != "":
+			newToken.value = resp.Header.Get(cfg.TokenHeader)
+		default:
+			newToken.value = strings.TrimSpace(string(respBody))
+		}
+		if newToken.value == "" {
+			return nil, errEmptyToken
+		}
+		if cfg.TTL > 0 {
+			newToken.expiresAt = time.Now().Add(time.Duration(cfg.TTL) * time.Second)
+		}
+
+		return newToken, nil
+	})
+}
+
+// cookieRecorder remember cookies of all responses of the login request(redirects included)
+type cookieRecorder struct {
+	http.CookieJar
+	cookies []*http.Cookie
+}
+
+func (r *cookieRecorder) SetCookies(u *url.URL, cookies []*http.Cookie) {
+	r.cookies = append(r.cookies, cookies...)
+	r.CookieJar.SetCookies(u, cookies)
+}
@@ -0,0 +1,120 @@
+package auth
+
+import (
+	"github.com/PxyUp/fitter/pkg/config"
+	"github.com/PxyUp/fitter/pkg/logger"
+	"io"
+	"net/http"
+)
+
+type transport struct {
+	cfg    *config.AuthConfig
+	host   string
+	base   http.RoundTripper
+	logger logger.Logger
+}
+
+// Transport wrap base round tripper with authentication from config, credentials are sent only to the host(with port) of the configured request,
+// so they are not leaked with redirects to other hosts
+func Transport(cfg *config.AuthConfig, host string, base http.RoundTripper, logger logger.Logger) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &transport{
+		cfg:    cfg,
+		host:   host,
+		base:   base,
+		logger: logger,
+	}
+}
+
+func (t *transport) authorizedRequest(req *http.Request) (*http.Request, error) {
+	headers, err := Headers(t.cfg, t.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	clone := req.Clone(req.Context())
+	SetHeaders(clone, headers)
+	return clone, nil
+}
+
+// retryRequest return copy of the request with fresh body or nil if body can not be replayed
+func retryRequest(req *http.Request) *http.Request {
+	clone := req.Clone(req.Context())
+	if req.Body == nil || req.Body == http.NoBody {
+		return clone
+	}
+
+	if req.GetBody == nil {
+		return nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil
+	}
+	clone.Body = body
+	return clone
+}
+
+func drain(resp *http.Response) {
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Host != t.host {
+		t.logger.Debugw("skip authentication for other host", "host", req.URL.Host)
+		return t.base.RoundTrip(req)
+	}
+
+	authReq, err := t.authorizedRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.base.RoundTrip(authReq)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	if t.cfg.Digest != nil {
+		challenge := resp.Header.Get("WWW-Authenticate")
+		retry := retryRequest(req)
+		if retry == nil || !isDigestChallenge(challenge) {
+			return resp, nil
+		}
+
+		username, password, _ := Credentials(t.cfg)
+		authorization, errDigest := digestAuthorization(challenge, retry.Method, retry.URL.RequestURI(), username, password)
+		if errDigest != nil {
+			t.logger.Errorw("unable to build digest authorization", "error", errDigest.Error())
+			return resp, nil
+		}
+
+		drain(resp)
+		retry.Header.Set(authorizationHeader, authorization)
+		return t.base.RoundTrip(retry)
+	}
+
+	if isRefreshable(t.cfg) {
+		retry := retryRequest(req)
+		if retry == nil {
+			return resp, nil
+		}
+
+		t.logger.Infow("got unauthorized response, refreshing token", "url", req.URL.String())
+		Invalidate(t.cfg)
+		retry, err = t.authorizedRequest(retry)
+		if err != nil {
+			return resp, nil
+		}
+
+		drain(resp)
+		return t.base.RoundTrip(retry)
+	}
+
+	return resp, nil
+}
@@ -16,10 +16,19 @@ const (
 	Json  ParserType = "json"
 	XML   ParserType = "XML"
 	XPath ParserType = "xpath"
+	Text  ParserType = "text"
 )
 
 type HostRequestLimiter map[string]int64
 type RefMap map[string]*Reference
+type SessionMap map[string]*SessionConfig
+
+type SessionConfig struct {
+	// CookieFile path to the file with cookies in Netscape format which used for seed the session
+	CookieFile string `json:"cookie_file" yaml:"cookie_file"`
+	// PersistPath path to the file where cookies of the session stored(Netscape format) between runs
+	PersistPath string `json:"persist_path" yaml:"persist_path"`
+}
 
 type Reference struct {
 	*ModelField
@@ -32,13 +41,39 @@ type Limits struct {
 	ChromiumInstance   uint32             `yaml:"chromium_instance" json:"chromium_instance"`
 	DockerContainers   uint32             `yaml:"docker_containers" json:"docker_containers"`
 	PlaywrightInstance uint32             `yaml:"playwright_instance" json:"playwright_instance"`
+	ProxyPools         ProxyPools         `yaml:"proxy_pools" json:"proxy_pools"`
+	// MaxBodySize global limit of the response body size in bytes, connector limit has priority
+	MaxBodySize int64 `yaml:"max_body_size" json:"max_body_size"`
+}
+
+type ProxyPools map[string]*ProxyPoolConfig
+
+type ProxyPoolStrategy string
+
+const (
+	RoundRobin ProxyPoolStrategy = "round_robin"
+	Random     ProxyPoolStrategy = "random"
+	Sticky     ProxyPoolStrategy = "sticky"
+)
+
+type ProxyPoolConfig struct {
+	Proxies []*ProxyConfig `yaml:"proxies" json:"proxies"`
+	// Strategy of proxy selection, default is round_robin. Sticky use same proxy per host
+	Strategy ProxyPoolStrategy `yaml:"strategy" json:"strategy"`
+	// MaxFailures amount of failures in a row after which proxy marked as unhealthy, default is 3
+	MaxFailures uint32 `yaml:"max_failures" json:"max_failures"`
+	// Cooldown in seconds while proxy is unhealthy, default is 60
+	Cooldown uint32 `yaml:"cooldown" json:"cooldown"`
+	// BanStatusCodes mark proxy unhealthy immediately, default is 403 and 429
+	BanStatusCodes []int `yaml:"ban_status_codes" json:"ban_status_codes"`
 }
 
 type Config struct {
 	Items []*Item `yaml:"items" json:"items"`
 
-	Limits     *Limits `yaml:"limits" json:"limits"`
-	References RefMap  `json:"references" yaml:"references"`
+	Limits     *Limits    `yaml:"limits" json:"limits"`
+	References RefMap     `json:"references" yaml:"references"`
+	Sessions   SessionMap `json:"sessions" yaml:"sessions"`
 
 	HttpServer *HttpServerCfg `json:"http_server" yaml:"http_server"`
 }
@@ -50,8 +85,9 @@ type HttpServerCfg struct {
 type CliItem struct {
 	Item *Item `yaml:"item" json:"item"`
 
-	Limits     *Limits `yaml:"limits" json:"limits"`
-	References RefMap  `json:"references" yaml:"references"`
+	Limits     *Limits    `yaml:"limits" json:"limits"`
+	References RefMap     `json:"references" yaml:"references"`
+	Sessions   SessionMap `json:"sessions" yaml:"sessions"`
 }
 
 type ObjectConfig struct {
@@ -95,11 +131,113 @@ type ConnectorConfig struct {
 	PluginConnectorConfig *PluginConnectorConfig      `json:"plugin_connector_config" yaml:"plugin_connector_config"`
 	ReferenceConfig       *ReferenceConnectorConfig   `yaml:"reference_config" json:"reference_config"`
 	FileConfig            *FileConnectorConfig        `json:"file_config" yaml:"file_config"`
+	GraphQLConfig         *GraphQLConnectorConfig     `json:"graphql_config" yaml:"graphql_config"`
+	WebSocketConfig       *WebSocketConnectorConfig   `json:"websocket_config" yaml:"websocket_config"`
+	SSEConfig             *SSEConnectorConfig         `json:"sse_config" yaml:"sse_config"`
+	GRPCConfig            *GRPCConnectorConfig        `json:"grpc_config" yaml:"grpc_config"`
+	SQLConfig             *SQLConnectorConfig         `json:"sql_config" yaml:"sql_config"`
+
+	DocumentConfig *DocumentConfig `json:"document_config" yaml:"document_config"`
+}
+
+type DocumentFormat string
+
+const (
+	PDF  DocumentFormat = "pdf"
+	XLSX DocumentFormat = "xlsx"
+	ODS  DocumentFormat = "ods"
+)
+
+type DocumentConfig struct {
+	// Format of the document, detected from content if empty
+	Format DocumentFormat `json:"format" yaml:"format"`
+	// HeaderRow use first row of the sheet as keys for other rows
+	HeaderRow bool `json:"header_row" yaml:"header_row"`
 }
 
 type FileConnectorConfig struct {
 	Path          string `yaml:"path" json:"path"`
 	UseFormatting bool   `yaml:"use_formatting" json:"use_formatting"`
+	// MaxBodySize limit of the file size in bytes
+	MaxBodySize int64 `yaml:"max_body_size" json:"max_body_size"`
+}
+
+type GraphQLConnectorConfig struct {
+	Query string `json:"query" yaml:"query"`
+	// Variables json object of the variables, support formatting
+	Variables     json.RawMessage   `json:"variables" yaml:"variables"`
+	OperationName string            `json:"operation_name" yaml:"operation_name"`
+	Headers       map[string]string `json:"headers" yaml:"headers"`
+	// IgnoreErrors return data even if response contains errors
+	IgnoreErrors bool `json:"ignore_errors" yaml:"ignore_errors"`
+
+	// Server settings of the request(timeout, proxy, auth and etc.), method and body are ignored
+	Server     *ServerConnectorConfig   `json:"server" yaml:"server"`
+	Pagination *GraphQLPaginationConfig `json:"pagination" yaml:"pagination"`
+}
+
+type SQLDriver string
+
+const (
+	SQLite   SQLDriver = "sqlite"
+	Postgres SQLDriver = "postgres"
+)
+
+type SQLConnectorConfig struct {
+	Driver SQLDriver `json:"driver" yaml:"driver"`
+	// DSN of the database, support formatting
+	DSN   string `json:"dsn" yaml:"dsn"`
+	Query string `json:"query" yaml:"query"`
+	// Params of the query which bound as arguments($1 for postgres, ? for sqlite), support formatting
+	Params  []string `json:"params" yaml:"params"`
+	Timeout uint32   `json:"timeout" yaml:"timeout"`
+}
+
+type GRPCConnectorConfig struct {
+	// Target address of the server(host:port), support formatting
+	Target string `json:"target" yaml:"target"`
+	// Method full name of the method(package.Service/Method)
+	Method string `json:"method" yaml:"method"`
+	// Body json request message, support formatting
+	Body json.RawMessage `json:"body" yaml:"body"`
+	// Metadata headers of the request, support formatting
+	Metadata map[string]string `json:"metadata" yaml:"metadata"`
+	// DescriptorSetFile path of the FileDescriptorSet(protoc --include_imports --descriptor_set_out), server reflection is used if empty
+	DescriptorSetFile string `json:"descriptor_set_file" yaml:"descriptor_set_file"`
+	// UseProtoNames use original field names from proto file instead of lowerCamelCase in response
+	UseProtoNames bool   `json:"use_proto_names" yaml:"use_proto_names"`
+	Timeout       uint32 `json:"timeout" yaml:"timeout"`
+
+	// TLS of the connection, plaintext connection is used if empty
+	TLS *TLSConfig `json:"tls" yaml:"tls"`
+}
+
+type WebSocketConnectorConfig struct {
+	Headers map[string]string `json:"headers" yaml:"headers"`
+	// Subscribe message which is sent after connect, support formatting
+	Subscribe string `json:"subscribe" yaml:"subscribe"`
+	// MaxMessages amount of messages for collect, 1 if MaxMessages and Window are empty
+	MaxMessages uint32 `json:"max_messages" yaml:"max_messages"`
+	// Window in seconds for collect messages
+	Window uint32 `json:"window" yaml:"window"`
+}
+
+type SSEConnectorConfig struct {
+	Headers map[string]string `json:"headers" yaml:"headers"`
+	// Event name of the events for collect, "message" by default
+	Event       string `json:"event" yaml:"event"`
+	MaxMessages uint32 `json:"max_messages" yaml:"max_messages"`
+	Window      uint32 `json:"window" yaml:"window"`
+}
+
+type GraphQLPaginationConfig struct {
+	// PageInfoPath path to pageInfo object(hasNextPage, endCursor) inside data
+	PageInfoPath string `json:"page_info_path" yaml:"page_info_path"`
+	// ItemsPath path to array of items inside data, items of all pages are concatenated
+	ItemsPath string `json:"items_path" yaml:"items_path"`
+	// CursorVariable name of the variable for cursor of the next page
+	CursorVariable string `json:"cursor_variable" yaml:"cursor_variable"`
+	MaxPages       uint32 `json:"max_pages" yaml:"max_pages"`
 }
 
 type IntSequenceConnectorConfig struct {
@@ -130,6 +268,9 @@ type PlaywrightConfig struct {
 	Stealth      bool                       `json:"stealth" yaml:"stealth"`
 
 	Proxy *ProxyConfig `json:"proxy" yaml:"proxy"`
+	// ProxyPool name of the proxy pool from limits, used if Proxy is empty
+	ProxyPool string      `json:"proxy_pool" yaml:"proxy_pool"`
+	Auth      *AuthConfig `json:"auth" yaml:"auth"`
 }
 
 type StaticConnectorConfig struct {
@@ -171,8 +312,111 @@ type ServerConnectorConfig struct {
 	Headers map[string]string `yaml:"headers" json:"headers"`
 	Timeout uint32            `yaml:"timeout" json:"timeout"`
 	Body    string            `yaml:"body" json:"body"`
+	// Session name of the session from config which cookies shared between requests
+	Session string `yaml:"session" json:"session"`
+	// ProxyPool name of the proxy pool from limits, used if Proxy is empty
+	ProxyPool string `yaml:"proxy_pool" json:"proxy_pool"`
+	// Charset of the response, detected from Content-Type, xml declaration or meta tags if empty
+	Charset string `yaml:"charset" json:"charset"`
+	// MaxBodySize limit of the response body size in bytes
+	MaxBodySize int64 `yaml:"max_body_size" json:"max_body_size"`
+	// Form url encoded form fields, used instead of Body
+	Form map[string]string `yaml:"form" json:"form"`
+	// Multipart parts of multipart/form-data body, used instead of Body and Form
+	Multipart []*MultipartPart `yaml:"multipart" json:"multipart"`
+	// JSON object built from fields with parsed value as source, used instead of Body, Form and Multipart
+	JSON *ObjectConfig `yaml:"json" json:"json"`
+
+	Proxy     *ProxyConfig     `yaml:"proxy" json:"proxy"`
+	Auth      *AuthConfig      `yaml:"auth" json:"auth"`
+	TLS       *TLSConfig       `yaml:"tls" json:"tls"`
+	Transport *TransportConfig `yaml:"transport" json:"transport"`
+}
+
+type MultipartPart struct {
+	Name  string `yaml:"name" json:"name"`
+	Value string `yaml:"value" json:"value"`
+	// File path of the file for upload, Value is ignored if File is set
+	File string `yaml:"file" json:"file"`
+	// FileName of the part, base name of the File is used if empty
+	FileName    string `yaml:"file_name" json:"file_name"`
+	ContentType string `yaml:"content_type" json:"content_type"`
+}
+
+type TLSConfig struct {
+	// CAFile path to PEM file with additional root certificates
+	CAFile string `json:"ca_file" yaml:"ca_file"`
+	// CertFile and KeyFile paths to PEM files of client certificate
+	CertFile           string `json:"cert_file" yaml:"cert_file"`
+	KeyFile            string `json:"key_file" yaml:"key_file"`
+	InsecureSkipVerify bool   `json:"insecure_skip_verify" yaml:"insecure_skip_verify"`
+	// MinVersion minimal version of TLS: 1.0, 1.1, 1.2, 1.3
+	MinVersion string `json:"min_version" yaml:"min_version"`
+	ServerName string `json:"server_name" yaml:"server_name"`
+}
+
+type TransportConfig struct {
+	DisableHTTP2        bool `json:"disable_http2" yaml:"disable_http2"`
+	DisableKeepAlives   bool `json:"disable_keep_alives" yaml:"disable_keep_alives"`
+	MaxIdleConns        int  `json:"max_idle_conns" yaml:"max_idle_conns"`
+	MaxIdleConnsPerHost int  `json:"max_idle_conns_per_host" yaml:"max_idle_conns_per_host"`
+	MaxConnsPerHost     int  `json:"max_conns_per_host" yaml:"max_conns_per_host"`
+	// IdleConnTimeout in seconds
+	IdleConnTimeout uint32 `json:"idle_conn_timeout" yaml:"idle_conn_timeout"`
+}
+
+type AuthConfig struct {
+	Basic                   *BasicAuthConfig                   `json:"basic" yaml:"basic"`
+	Digest                  *BasicAuthConfig                   `json:"digest" yaml:"digest"`
+	Bearer                  *BearerAuthConfig                  `json:"bearer" yaml:"bearer"`
+	OAuth2ClientCredentials *OAuth2ClientCredentialsAuthConfig `json:"oauth2_client_credentials" yaml:"oauth2_client_credentials"`
+	FormLogin               *FormLoginAuthConfig               `json:"form_login" yaml:"form_login"`
+}
+
+type BasicAuthConfig struct {
+	Username string `json:"username" yaml:"username"`
+	Password string `json:"password" yaml:"password"`
+}
+
+type BearerAuthConfig struct {
+	// Token value, support FromEnv= and other placeholders
+	Token string `json:"token" yaml:"token"`
+	// TokenFile path to the file with token, file is read on each request
+	TokenFile string `json:"token_file" yaml:"token_file"`
+}
 
-	Proxy *ProxyConfig `yaml:"proxy" json:"proxy"`
+type OAuth2ClientCredentialsAuthConfig struct {
+	TokenURL     string            `json:"token_url" yaml:"token_url"`
+	ClientID     string            `json:"client_id" yaml:"client_id"`
+	ClientSecret string            `json:"client_secret" yaml:"client_secret"`
+	Scopes       []string          `json:"scopes" yaml:"scopes"`
+	Params       map[string]string `json:"params" yaml:"params"`
+}
+
+type FormLoginAuthConfig struct {
+	Url     string            `json:"url" yaml:"url"`
+	Method  string            `json:"method" yaml:"method"`
+	Headers map[string]string `json:"headers" yaml:"headers"`
+	// Form values which sent as application/x-www-form-urlencoded body
+	Form map[string]string `json:"form" yaml:"form"`
+	// Body raw body of the request, used if Form is empty
+	Body string `json:"body" yaml:"body"`
+	// Session name of the session which receive cookies of the login response
+	Session string `json:"session" yaml:"session"`
+
+	// TokenPath json path of the token in login response
+	TokenPath string `json:"token_path" yaml:"token_path"`
+	// TokenCookie name of the cookie with token in login response
+	TokenCookie string `json:"token_cookie" yaml:"token_cookie"`
+	// TokenHeader name of the header with token in login response
+	TokenHeader string `json:"token_header" yaml:"token_header"`
+
+	// Header name of the header for inject token, default is Authorization(Cookie for TokenCookie)
+	Header string `json:"header" yaml:"header"`
+	// Prefix of the token in header, default is "Bearer " for Authorization header
+	Prefix *string `json:"prefix" yaml:"prefix"`
+	// TTL of the token in seconds, token is cached until 401 response if empty
+	TTL uint32 `json:"ttl" yaml:"ttl"`
 }
 
 type ProxyConfig struct {
@@ -189,6 +433,23 @@ type ProxyConfig struct {
 type TriggerConfig struct {
 	SchedulerTrigger *SchedulerTrigger `yaml:"scheduler_trigger" json:"scheduler_trigger"`
 	HTTPTrigger      *HTTPTrigger      `json:"http_trigger" yaml:"http_trigger"`
+	WebSocketTrigger *WebSocketTrigger `json:"websocket_trigger" yaml:"websocket_trigger"`
+	SSETrigger       *SSETrigger       `json:"sse_trigger" yaml:"sse_trigger"`
+}
+
+type WebSocketTrigger struct {
+	Url       string            `json:"url" yaml:"url"`
+	Headers   map[string]string `json:"headers" yaml:"headers"`
+	Subscribe string            `json:"subscribe" yaml:"subscribe"`
+	// Reconnect delay in seconds before reconnect after connection is lost
+	Reconnect uint32 `json:"reconnect" yaml:"reconnect"`
+}
+
+type SSETrigger struct {
+	Url       string            `json:"url" yaml:"url"`
+	Headers   map[string]string `json:"headers" yaml:"headers"`
+	Event     string            `json:"event" yaml:"event"`
+	Reconnect uint32            `json:"reconnect" yaml:"reconnect"`
 }
 
 type SchedulerTrigger struct {
@@ -199,17 +460,135 @@ type SchedulerTrigger struct {
 type HTTPTrigger struct {
 }
 
+type TemplateMode string
+
+const (
+	TextTemplate TemplateMode = "text"
+	HTMLTemplate TemplateMode = "html"
+)
+
 type NotifierConfig struct {
 	Expression      string `yaml:"expression" json:"expression"`
 	Force           bool   `json:"force" yaml:"force"`
 	SendArrayByItem bool   `yaml:"send_array_by_item" json:"send_array_by_item"`
 	Template        string `yaml:"template" json:"template"`
+	// TemplateMode render Template as Go template for each notification, placeholders are used if empty
+	TemplateMode TemplateMode `yaml:"template_mode" json:"template_mode"`
+	// Delivery retry, dead letter and async settings of the notifier
+	Delivery *DeliveryConfig `yaml:"delivery" json:"delivery"`
+	// Batch group records into chunks which are sent like one notification
+	Batch *BatchConfig `yaml:"batch" json:"batch"`
+	// Digest collect records of several runs and send them like one notification per interval, Batch is ignored if set
+	Digest *DigestConfig `yaml:"digest" json:"digest"`
+
+	Console     *ConsoleConfig             `yaml:"console" json:"console"`
+	TelegramBot *TelegramBotConfig         `yaml:"telegram_bot" json:"telegram_bot"`
+	Http        *HttpConfig                `yaml:"http" json:"http"`
+	Redis       *RedisNotifierConfig       `json:"redis" yaml:"redis"`
+	File        *FileStorageField          `json:"file" yaml:"file"`
+	SQL         *SQLNotifierConfig         `json:"sql" yaml:"sql"`
+	RedisStream *RedisStreamNotifierConfig `json:"redis_stream" yaml:"redis_stream"`
+	NATS        *NATSNotifierConfig        `json:"nats" yaml:"nats"`
+	Kafka       *KafkaNotifierConfig       `json:"kafka" yaml:"kafka"`
+	AMQP        *AMQPNotifierConfig        `json:"amqp" yaml:"amqp"`
+	Slack       *SlackNotifierConfig       `json:"slack" yaml:"slack"`
+	Discord     *DiscordNotifierConfig     `json:"discord" yaml:"discord"`
+	Matrix      *MatrixNotifierConfig      `json:"matrix" yaml:"matrix"`
+	SMTP        *SMTPNotifierConfig        `json:"smtp" yaml:"smtp"`
+}
 
-	Console     *ConsoleConfig       `yaml:"console" json:"console"`
-	TelegramBot *TelegramBotConfig   `yaml:"telegram_bot" json:"telegram_bot"`
-	Http        *HttpConfig          `yaml:"http" json:"http"`
-	Redis       *RedisNotifierConfig `json:"redis" yaml:"redis"`
-	File        *FileStorageField    `json:"file" yaml:"file"`
+type DeliveryConfig struct {
+	// Attempts amount of attempts of the notification, 1 by default
+	Attempts uint32 `json:"attempts" yaml:"attempts"`
+	// Backoff delay before second attempt in milliseconds, doubled for each next attempt. 1000 by default
+	Backoff uint32 `json:"backoff" yaml:"backoff"`
+	// MaxBackoff max delay between attempts in milliseconds, 30000 by default
+	MaxBackoff uint32 `json:"max_backoff" yaml:"max_backoff"`
+	// ContinueOnError notify about next items of the array if item is failed
+	ContinueOnError bool `json:"continue_on_error" yaml:"continue_on_error"`
+	// Async notify in background, processing does not wait for notification
+	Async bool `json:"async" yaml:"async"`
+	// QueueSize size of the async queue, processing waits if queue is full. 100 by default
+	QueueSize uint32 `json:"queue_size" yaml:"queue_size"`
+	// DeadLetter directory for failed notifications, they can be replayed with -replay flag
+	DeadLetter string `json:"dead_letter" yaml:"dead_letter"`
+}
+
+type BatchConfig struct {
+	// Size max amount of records in the chunk
+	Size uint32 `json:"size" yaml:"size"`
+	// MaxWait max time in milliseconds for waiting records of next runs before not full chunk is sent, chunk is sent at end of the run if empty
+	MaxWait uint32 `json:"max_wait" yaml:"max_wait"`
+}
+
+type DigestConfig struct {
+	// Interval of the digest in seconds, digest is sent at start of each interval: 3600 - each hour
+	Interval uint32 `json:"interval" yaml:"interval"`
+	// MaxItems send digest before end of the interval if amount of records is reached, unlimited if empty
+	MaxItems uint32 `json:"max_items" yaml:"max_items"`
+}
+
+type RedisStreamNotifierConfig struct {
+	Addr     string `json:"addr" yaml:"addr"`
+	Password string `json:"password" yaml:"password"`
+	DB       int    `json:"db" yaml:"db"`
+	// Stream name of the stream, support formatting
+	Stream string `json:"stream" yaml:"stream"`
+	// MaxLen trim stream to approximately MaxLen entries, stream is not trimmed if empty
+	MaxLen int64 `json:"max_len" yaml:"max_len"`
+	// KeyPath json path inside result for key of the message
+	KeyPath string `json:"key_path" yaml:"key_path"`
+	// Headers additional fields of the entry, support formatting
+	Headers   map[string]string `json:"headers" yaml:"headers"`
+	BatchSize uint32            `json:"batch_size" yaml:"batch_size"`
+}
+
+type NATSNotifierConfig struct {
+	Url string `json:"url" yaml:"url"`
+	// Subject support formatting
+	Subject string `json:"subject" yaml:"subject"`
+	// JetStream publish with acknowledgement from JetStream, key is used as Nats-Msg-Id for deduplication
+	JetStream bool              `json:"jet_stream" yaml:"jet_stream"`
+	KeyPath   string            `json:"key_path" yaml:"key_path"`
+	Headers   map[string]string `json:"headers" yaml:"headers"`
+	BatchSize uint32            `json:"batch_size" yaml:"batch_size"`
+}
+
+type KafkaNotifierConfig struct {
+	Brokers []string `json:"brokers" yaml:"brokers"`
+	// Topic support formatting
+	Topic     string            `json:"topic" yaml:"topic"`
+	KeyPath   string            `json:"key_path" yaml:"key_path"`
+	Headers   map[string]string `json:"headers" yaml:"headers"`
+	BatchSize uint32            `json:"batch_size" yaml:"batch_size"`
+}
+
+type AMQPNotifierConfig struct {
+	Url      string `json:"url" yaml:"url"`
+	Exchange string `json:"exchange" yaml:"exchange"`
+	// RoutingKey support formatting
+	RoutingKey string `json:"routing_key" yaml:"routing_key"`
+	// KeyPath json path inside result for message id
+	KeyPath   string            `json:"key_path" yaml:"key_path"`
+	Headers   map[string]string `json:"headers" yaml:"headers"`
+	BatchSize uint32            `json:"batch_size" yaml:"batch_size"`
+}
+
+type SQLNotifierConfig struct {
+	Driver SQLDriver `json:"driver" yaml:"driver"`
+	// DSN of the database, support formatting
+	DSN   string `json:"dsn" yaml:"dsn"`
+	Table string `json:"table" yaml:"table"`
+	// Columns map column name to json path inside result, whole result is used for empty path
+	Columns map[string]string `json:"columns" yaml:"columns"`
+	// Key columns of the unique key for upsert, rows are only inserted if empty
+	Key []string `json:"key" yaml:"key"`
+	// CreateTable create table if not exists
+	CreateTable bool `json:"create_table" yaml:"create_table"`
+	// ColumnTypes sql types of the columns for CreateTable, TEXT by default
+	ColumnTypes map[string]string `json:"column_types" yaml:"column_types"`
+	// BatchSize amount of rows in one transaction, 100 by default
+	BatchSize uint32 `json:"batch_size" yaml:"batch_size"`
 }
 
 type HttpConfig struct {
@@ -217,6 +596,38 @@ type HttpConfig struct {
 	Method  string            `json:"method" yaml:"method"`
 	Headers map[string]string `yaml:"headers" json:"headers"`
 	Timeout uint32            `yaml:"timeout" json:"timeout"`
+	// Body formatted body of the request, json of the record is sent if empty
+	Body string `yaml:"body" json:"body"`
+	// OnlyMsg send result without envelope, string results(for example from Template) are sent without quotes
+	OnlyMsg bool `yaml:"only_msg" json:"only_msg"`
+	// ContentType of the request, application/json by default
+	ContentType string `yaml:"content_type" json:"content_type"`
+	// ExpectedStatus list of success status codes, any 2xx by default
+	ExpectedStatus []int `yaml:"expected_status" json:"expected_status"`
+	// Signature sign body of the request with HMAC-SHA256
+	Signature *HttpSignatureConfig `yaml:"signature" json:"signature"`
+	// TLS client certificates and CA of the server
+	TLS *TLSConfig `yaml:"tls" json:"tls"`
+}
+
+type SignatureEncoding string
+
+const (
+	HexSignature    SignatureEncoding = "hex"
+	Base64Signature SignatureEncoding = "base64"
+)
+
+type HttpSignatureConfig struct {
+	// Secret formatted key of the HMAC
+	Secret string `yaml:"secret" json:"secret"`
+	// Header for signature, X-Signature by default
+	Header string `yaml:"header" json:"header"`
+	// Prefix of the signature value, for example "sha256="
+	Prefix string `yaml:"prefix" json:"prefix"`
+	// Encoding of the signature: hex(default) or base64
+	Encoding SignatureEncoding `yaml:"encoding" json:"encoding"`
+	// TimestampHeader header for unix timestamp of the request, "timestamp.body" is signed if set
+	TimestampHeader string `yaml:"timestamp_header" json:"timestamp_header"`
 }
 
 type ConsoleConfig struct {
@@ -235,6 +646,78 @@ type TelegramBotConfig struct {
 	UsersId []int64 `json:"users_id" yaml:"users_id"`
 	Pretty  bool    `json:"pretty" yaml:"pretty"`
 	OnlyMsg bool    `json:"only_msg" yaml:"only_msg"`
+	// ParseMode one of "Markdown", "MarkdownV2", "HTML"
+	ParseMode string `json:"parse_mode" yaml:"parse_mode"`
+	// Photo url or local path of the photo, message is used as caption. Support formatting
+	Photo string `json:"photo" yaml:"photo"`
+	// Document url or local path of the document, message is used as caption. Support formatting
+	Document string `json:"document" yaml:"document"`
+}
+
+type SlackNotifierConfig struct {
+	// WebhookUrl url of the incoming webhook, support formatting
+	WebhookUrl string `json:"webhook_url" yaml:"webhook_url"`
+	// Blocks Block Kit blocks of the message, support formatting
+	Blocks    json.RawMessage `json:"blocks" yaml:"blocks"`
+	Channel   string          `json:"channel" yaml:"channel"`
+	Username  string          `json:"username" yaml:"username"`
+	IconEmoji string          `json:"icon_emoji" yaml:"icon_emoji"`
+	Pretty    bool            `json:"pretty" yaml:"pretty"`
+	OnlyMsg   bool            `json:"only_msg" yaml:"only_msg"`
+	// Truncate message which is longer than limit instead of split into several messages
+	Truncate bool   `json:"truncate" yaml:"truncate"`
+	Timeout  uint32 `json:"timeout" yaml:"timeout"`
+}
+
+type DiscordNotifierConfig struct {
+	// WebhookUrl url of the webhook, support formatting
+	WebhookUrl string              `json:"webhook_url" yaml:"webhook_url"`
+	Username   string              `json:"username" yaml:"username"`
+	AvatarUrl  string              `json:"avatar_url" yaml:"avatar_url"`
+	Embed      *DiscordEmbedConfig `json:"embed" yaml:"embed"`
+	Pretty     bool                `json:"pretty" yaml:"pretty"`
+	OnlyMsg    bool                `json:"only_msg" yaml:"only_msg"`
+	Truncate   bool                `json:"truncate" yaml:"truncate"`
+	Timeout    uint32              `json:"timeout" yaml:"timeout"`
+}
+
+// DiscordEmbedConfig send message as description of the embed, all fields support formatting
+type DiscordEmbedConfig struct {
+	Title  string `json:"title" yaml:"title"`
+	Url    string `json:"url" yaml:"url"`
+	Color  int    `json:"color" yaml:"color"`
+	Footer string `json:"footer" yaml:"footer"`
+}
+
+type MatrixNotifierConfig struct {
+	Homeserver  string `json:"homeserver" yaml:"homeserver"`
+	AccessToken string `json:"access_token" yaml:"access_token"`
+	RoomId      string `json:"room_id" yaml:"room_id"`
+	// MsgType m.text by default
+	MsgType string `json:"msg_type" yaml:"msg_type"`
+	// FormattedBody html body of the message, support formatting
+	FormattedBody string `json:"formatted_body" yaml:"formatted_body"`
+	Pretty        bool   `json:"pretty" yaml:"pretty"`
+	OnlyMsg       bool   `json:"only_msg" yaml:"only_msg"`
+	Truncate      bool   `json:"truncate" yaml:"truncate"`
+	Timeout       uint32 `json:"timeout" yaml:"timeout"`
+}
+
+type SMTPNotifierConfig struct {
+	Host     string `json:"host" yaml:"host"`
+	Port     int    `json:"port" yaml:"port"`
+	Username string `json:"username" yaml:"username"`
+	Password string `json:"password" yaml:"password"`
+	// TLS use implicit TLS connection, STARTTLS is used if server support it otherwise
+	TLS  bool     `json:"tls" yaml:"tls"`
+	From string   `json:"from" yaml:"from"`
+	To   []string `json:"to" yaml:"to"`
+	// Subject, Text and HTML support formatting
+	Subject string `json:"subject" yaml:"subject"`
+	Text    string `json:"text" yaml:"text"`
+	HTML    string `json:"html" yaml:"html"`
+	Pretty  bool   `json:"pretty" yaml:"pretty"`
+	OnlyMsg bool   `json:"only_msg" yaml:"only_msg"`
 }
 
 type Item struct {
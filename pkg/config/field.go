@@ -32,6 +32,12 @@ type BaseField struct {
 	Path string    `yaml:"path" json:"path"`
 
 	HTMLAttribute string `json:"html_attribute" yaml:"html_attribute"`
+	// Attribute of the element which used instead of the text, works for HTML/XPath/XML
+	Attribute string `json:"attribute" yaml:"attribute"`
+	// Attributes produce object with value of each attribute of the element, "#text" is used for text of the element
+	Attributes []string `json:"attributes" yaml:"attributes"`
+	// ResolveURL resolve relative url attributes(href, src and etc.) or text value against final page url and <base href>
+	ResolveURL bool `json:"resolve_url" yaml:"resolve_url"`
 
 	Generated *GeneratedFieldConfig `yaml:"generated" json:"generated"`
 
@@ -60,6 +66,56 @@ type FileStorageField struct {
 	FileName string `json:"file_name" yaml:"file_name"`
 	Path     string `json:"path" yaml:"path"`
 	Append   bool   `json:"append" yaml:"append"`
+
+	// S3 store file into S3-compatible bucket, Path and FileName are used as key of the object
+	S3          *S3StorageConfig `json:"s3" yaml:"s3"`
+	ContentType string           `json:"content_type" yaml:"content_type"`
+	Gzip        bool             `json:"gzip" yaml:"gzip"`
+
+	// Format of the file, content is written as is if empty
+	Format FileFormat `json:"format" yaml:"format"`
+	// Columns of the csv and parquet formats
+	Columns  []*FileColumn `json:"columns" yaml:"columns"`
+	Rotation *FileRotation `json:"rotation" yaml:"rotation"`
+}
+
+type FileFormat string
+
+const (
+	JSONLFormat   FileFormat = "jsonl"
+	CSVFormat     FileFormat = "csv"
+	ParquetFormat FileFormat = "parquet"
+)
+
+type FileColumnType string
+
+const (
+	StringColumn FileColumnType = "string"
+	IntColumn    FileColumnType = "int"
+	FloatColumn  FileColumnType = "float"
+	BoolColumn   FileColumnType = "bool"
+)
+
+type FileColumn struct {
+	Name string `json:"name" yaml:"name"`
+	// Path json path inside result, whole result is used for empty path
+	Path string `json:"path" yaml:"path"`
+	// Type of the parquet column, string by default
+	Type FileColumnType `json:"type" yaml:"type"`
+}
+
+type RotationInterval string
+
+const (
+	HourRotation RotationInterval = "hour"
+	DayRotation  RotationInterval = "day"
+)
+
+type FileRotation struct {
+	// Interval add date of the interval to file name: results.jsonl -> results-2024-05-01.jsonl
+	Interval RotationInterval `json:"interval" yaml:"interval"`
+	// MaxSize in bytes, index is added to file name when file is larger: results.1.jsonl
+	MaxSize int64 `json:"max_size" yaml:"max_size"`
 }
 
 type FileFieldConfig struct {
@@ -68,6 +124,25 @@ type FileFieldConfig struct {
 	Url      string `yaml:"url" json:"url"`
 	FileName string `json:"file_name" yaml:"file_name"`
 	Path     string `json:"path" yaml:"path"`
+
+	// S3 store file into S3-compatible bucket, Path and FileName are used as key of the object
+	S3          *S3StorageConfig `json:"s3" yaml:"s3"`
+	ContentType string           `json:"content_type" yaml:"content_type"`
+	Gzip        bool             `json:"gzip" yaml:"gzip"`
+}
+
+type S3StorageConfig struct {
+	// Endpoint https://s3.{region}.amazonaws.com by default, for MinIO for example http://localhost:9000
+	Endpoint string `json:"endpoint" yaml:"endpoint"`
+	// Region us-east-1 by default
+	Region string `json:"region" yaml:"region"`
+	Bucket string `json:"bucket" yaml:"bucket"`
+	// AccessKeyId, SecretAccessKey and SessionToken are taken from AWS_* environment variables if empty
+	AccessKeyId     string `json:"access_key_id" yaml:"access_key_id"`
+	SecretAccessKey string `json:"secret_access_key" yaml:"secret_access_key"`
+	SessionToken    string `json:"session_token" yaml:"session_token"`
+	// PathStyle use endpoint/bucket/key urls instead of bucket.endpoint/key, required for MinIO
+	PathStyle bool `json:"path_style" yaml:"path_style"`
 }
 
 type CalculatedConfig struct {
@@ -27,23 +27,30 @@ func (c *browserConnector) WithLogger(logger logger.Logger) *browserConnector {
 }
 
 func (c *browserConnector) Get(parsedValue builder.Interfacable, index *uint32, input builder.Interfacable) ([]byte, error) {
+	_, body, err := c.GetWithURL(parsedValue, index, input)
+	return body, err
+}
+
+func (c *browserConnector) GetWithURL(parsedValue builder.Interfacable, index *uint32, input builder.Interfacable) (string, []byte, error) {
 	formattedURL := utils.Format(c.url, parsedValue, index, input)
 
 	if formattedURL == "" {
-		return nil, errEmpty
+		return "", nil, errEmpty
 	}
 
 	if c.cfg.Chromium != nil {
-		return getFromChromium(formattedURL, c.cfg.Chromium, c.logger.With("emulator", "chromium"))
+		body, err := getFromChromium(formattedURL, c.cfg.Chromium, c.logger.With("emulator", "chromium"))
+		return formattedURL, body, err
 	}
 
 	if c.cfg.Docker != nil {
-		return getFromDocker(formattedURL, c.cfg.Docker, c.logger.With("emulator", "docker"))
+		body, err := getFromDocker(formattedURL, c.cfg.Docker, c.logger.With("emulator", "docker"))
+		return formattedURL, body, err
 	}
 
 	if c.cfg.Playwright != nil {
 		return getFromPlaywright(formattedURL, c.cfg.Playwright, parsedValue, index, input, c.logger.With("emulator", "playwright"))
 	}
 
-	return nil, nil
+	return "", nil, nil
 }
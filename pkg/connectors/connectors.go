@@ -3,36 +3,78 @@ package connectors
 import (
 	"errors"
 	"github.com/PxyUp/fitter/pkg/builder"
+	"net/url"
 )
 
 var (
 	errMaxAttempt = errors.New("reach max attempt")
 	errEmpty      = errors.New("empty url")
+
+	errProxyPoolNotFound = errors.New("proxy pool not found")
 )
 
+func hostOf(rawURL string) string {
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return parsedURL.Hostname()
+}
+
+// originOf return scheme://host:port of the url
+func originOf(rawURL string) string {
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil || parsedURL.Host == "" {
+		return ""
+	}
+	return parsedURL.Scheme + "://" + parsedURL.Host
+}
+
 type Connector interface {
 	Get(parsedValue builder.Interfacable, index *uint32, input builder.Interfacable) ([]byte, error)
 }
 
+// URLConnector is connector which know final url(after redirects) of the fetched content
+type URLConnector interface {
+	Connector
+
+	GetWithURL(parsedValue builder.Interfacable, index *uint32, input builder.Interfacable) (string, []byte, error)
+}
+
+// GetWithURL return content and final url of the content, url is empty if connector not support it
+func GetWithURL(connector Connector, parsedValue builder.Interfacable, index *uint32, input builder.Interfacable) (string, []byte, error) {
+	if urlConnector, ok := connector.(URLConnector); ok {
+		return urlConnector.GetWithURL(parsedValue, index, input)
+	}
+
+	body, err := connector.Get(parsedValue, index, input)
+	return "", body, err
+}
+
 type attemptsConnector struct {
 	original Connector
 	attempts uint32
 }
 
-func (r *attemptsConnector) Get(parsedValue builder.Interfacable, index *uint32, input builder.Interfacable) ([]byte, error) {
+func (r *attemptsConnector) GetWithURL(parsedValue builder.Interfacable, index *uint32, input builder.Interfacable) (string, []byte, error) {
 	if r.attempts <= 0 {
-		return r.original.Get(parsedValue, index, input)
+		return GetWithURL(r.original, parsedValue, index, input)
 	}
 
 	for i := 0; i < int(r.attempts); i++ {
-		resp, err := r.original.Get(parsedValue, index, input)
+		url, resp, err := GetWithURL(r.original, parsedValue, index, input)
 		if err != nil || len(resp) == 0 {
 			continue
 		}
-		return resp, nil
+		return url, resp, nil
 	}
 
-	return nil, errMaxAttempt
+	return "", nil, errMaxAttempt
+}
+
+func (r *attemptsConnector) Get(parsedValue builder.Interfacable, index *uint32, input builder.Interfacable) ([]byte, error) {
+	_, body, err := r.GetWithURL(parsedValue, index, input)
+	return body, err
 }
 
 func WithAttempts(original Connector, attempts uint32) Connector {
@@ -46,13 +88,18 @@ type nullSafe struct {
 	original Connector
 }
 
-func (n *nullSafe) Get(parsedValue builder.Interfacable, index *uint32, input builder.Interfacable) ([]byte, error) {
-	resp, err := n.original.Get(parsedValue, index, input)
+func (n *nullSafe) GetWithURL(parsedValue builder.Interfacable, index *uint32, input builder.Interfacable) (string, []byte, error) {
+	url, resp, err := GetWithURL(n.original, parsedValue, index, input)
 	if err != nil {
-		return builder.NullValue.Raw(), nil
+		return "", builder.NullValue.Raw(), nil
 	}
 
-	return resp, nil
+	return url, resp, nil
+}
+
+func (n *nullSafe) Get(parsedValue builder.Interfacable, index *uint32, input builder.Interfacable) ([]byte, error) {
+	_, body, err := n.GetWithURL(parsedValue, index, input)
+	return body, err
 }
 
 func NullSafe(original Connector) Connector {
@@ -0,0 +1,231 @@
+package connectors
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
+	"golang.org/x/net/html/charset"
+	"io"
+	"mime"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	utf8Charset  = "utf-8"
+	prescanLimit = 1024
+)
+
+var (
+	errUnknownCharset = errors.New("unknown charset")
+
+	xmlEncodingRe = regexp.MustCompile(`^\s*<\?xml[^>]*encoding=["']([A-Za-z0-9._:-]+)["']`)
+	// metaCharsetRe match <meta charset="..."> and <meta http-equiv="Content-Type" content="text/html; charset=...">
+	metaCharsetRe = regexp.MustCompile(`(?i)<meta[^>]+charset\s*=\s*["']?([A-Za-z0-9._:-]+)`)
+
+	utf8BOM = []byte("\xef\xbb\xbf")
+
+	textualMediaTypes = []string{"html", "xml", "json", "javascript", "csv"}
+)
+
+type readCloser struct {
+	io.Reader
+	closer func() error
+}
+
+func (r *readCloser) Close() error {
+	return r.closer()
+}
+
+// decodeContentEncoding wrap body with decompressors from Content-Encoding header(applied in reverse order), empty body(HEAD, 204, 304) is not decoded
+func decodeContentEncoding(body io.Reader, contentEncoding string) (io.ReadCloser, error) {
+	var closers []func() error
+	buffered := &peekReader{reader: body}
+	if len(buffered.peek(1)) == 0 {
+		contentEncoding = ""
+	}
+	body = buffered
+
+	encodings := strings.Split(contentEncoding, ",")
+	for i := len(encodings) - 1; i >= 0; i-- {
+		switch strings.ToLower(strings.TrimSpace(encodings[i])) {
+		case "", "identity":
+		case "gzip", "x-gzip":
+			reader, err := gzip.NewReader(body)
+			if err != nil {
+				return nil, err
+			}
+			body = reader
+			closers = append(closers, reader.Close)
+		case "deflate":
+			body = newDeflateReader(body)
+		case "br":
+			body = brotli.NewReader(body)
+		case "zstd":
+			reader, err := zstd.NewReader(body)
+			if err != nil {
+				return nil, err
+			}
+			body = reader
+			closers = append(closers, func() error {
+				reader.Close()
+				return nil
+			})
+		}
+	}
+
+	return &readCloser{
+		Reader: body,
+		closer: func() error {
+			for _, closer := range closers {
+				_ = closer()
+			}
+			return nil
+		},
+	}, nil
+}
+
+// newDeflateReader support zlib wrapped deflate and raw deflate which some servers send
+func newDeflateReader(body io.Reader) io.Reader {
+	buffered := &peekReader{reader: body}
+	header := buffered.peek(2)
+	if len(header) == 2 && header[0]&0x0f == 8 && (uint16(header[0])<<8|uint16(header[1]))%31 == 0 {
+		reader, err := zlib.NewReader(buffered)
+		if err == nil {
+			return reader
+		}
+	}
+
+	return flate.NewReader(buffered)
+}
+
+type peekReader struct {
+	reader io.Reader
+	buf    []byte
+}
+
+func (p *peekReader) peek(n int) []byte {
+	buf := make([]byte, n)
+	read, _ := io.ReadFull(p.reader, buf)
+	p.buf = buf[:read]
+	return p.buf
+}
+
+func (p *peekReader) Read(dst []byte) (int, error) {
+	if len(p.buf) > 0 {
+		n := copy(dst, p.buf)
+		p.buf = p.buf[n:]
+		return n, nil
+	}
+	return p.reader.Read(dst)
+}
+
+func isTextual(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	if strings.HasPrefix(mediaType, "text/") {
+		return true
+	}
+
+	for _, textual := range textualMediaTypes {
+		if strings.Contains(mediaType, textual) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isJSON(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && strings.Contains(mediaType, "json")
+}
+
+// declaredCharset return charset from Content-Type, xml declaration or meta tag, empty if charset is not declared
+func declaredCharset(body []byte, contentType string) string {
+	if _, params, err := mime.ParseMediaType(contentType); err == nil && params["charset"] != "" {
+		return params["charset"]
+	}
+
+	prefix := body
+	if len(prefix) > prescanLimit {
+		prefix = prefix[:prescanLimit]
+	}
+	if match := xmlEncodingRe.FindSubmatch(prefix); match != nil {
+		return string(match[1])
+	}
+	if match := metaCharsetRe.FindSubmatch(prefix); match != nil {
+		return string(match[1])
+	}
+
+	return ""
+}
+
+// toUTF8 convert body into UTF-8, charset is taken from override, BOM, Content-Type, xml declaration or meta tags.
+// JSON is always UTF-8, valid UTF-8 body and body without declared charset are returned as is
+func toUTF8(body []byte, contentType string, override string) ([]byte, error) {
+	if override == "" && !isTextual(contentType) {
+		return body, nil
+	}
+
+	name := override
+	if name == "" {
+		if bytes.HasPrefix(body, utf8BOM) || isJSON(contentType) {
+			return bytes.TrimPrefix(body, utf8BOM), nil
+		}
+
+		if _, bomName, certain := charset.DetermineEncoding(body, ""); certain {
+			name = bomName
+		} else if utf8.Valid(body) {
+			return replaceXMLEncoding(body), nil
+		} else {
+			name = declaredCharset(body, contentType)
+		}
+
+		if name == "" {
+			return body, nil
+		}
+	}
+
+	encoding, canonicalName := charset.Lookup(name)
+	if encoding == nil {
+		return nil, errUnknownCharset
+	}
+
+	if canonicalName == utf8Charset {
+		return bytes.TrimPrefix(body, utf8BOM), nil
+	}
+
+	decoded, err := encoding.NewDecoder().Bytes(body)
+	if err != nil {
+		return nil, err
+	}
+
+	return replaceXMLEncoding(decoded), nil
+}
+
+// replaceXMLEncoding point xml declaration to UTF-8, otherwise xml parser decode content one more time
+func replaceXMLEncoding(body []byte) []byte {
+	prefix := body
+	if len(prefix) > prescanLimit {
+		prefix = prefix[:prescanLimit]
+	}
+
+	match := xmlEncodingRe.FindSubmatchIndex(prefix)
+	if match == nil {
+		return body
+	}
+
+	result := make([]byte, 0, len(body))
+	result = append(result, body[:match[2]]...)
+	result = append(result, "UTF-8"...)
+	return append(result, body[match[3]:]...)
+}
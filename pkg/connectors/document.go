@@ -0,0 +1,145 @@
+package connectors
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/PxyUp/fitter/pkg/builder"
+	"github.com/PxyUp/fitter/pkg/config"
+	"github.com/PxyUp/fitter/pkg/logger"
+)
+
+var (
+	errUnknownDocumentFormat = errors.New("unknown document format")
+)
+
+type documentConnector struct {
+	original Connector
+	cfg      *config.DocumentConfig
+	logger   logger.Logger
+}
+
+type documentPage struct {
+	Page  int      `json:"page"`
+	Text  string   `json:"text"`
+	Lines []string `json:"lines"`
+}
+
+type documentSheet struct {
+	Name string        `json:"name"`
+	Rows []interface{} `json:"rows"`
+}
+
+type documentResult struct {
+	Pages  []*documentPage  `json:"pages,omitempty"`
+	Sheets []*documentSheet `json:"sheets,omitempty"`
+}
+
+func (d *documentConnector) Get(parsedValue builder.Interfacable, index *uint32, input builder.Interfacable) ([]byte, error) {
+	_, body, err := d.GetWithURL(parsedValue, index, input)
+	return body, err
+}
+
+func (d *documentConnector) GetWithURL(parsedValue builder.Interfacable, index *uint32, input builder.Interfacable) (string, []byte, error) {
+	url, body, err := GetWithURL(d.original, parsedValue, index, input)
+	if err != nil {
+		return "", nil, err
+	}
+
+	format := d.cfg.Format
+	if format == "" {
+		format = detectDocumentFormat(body)
+	}
+
+	result := &documentResult{}
+	switch format {
+	case config.PDF:
+		result.Pages, err = extractPDF(body)
+	case config.XLSX:
+		result.Sheets, err = extractXLSX(body, d.cfg.HeaderRow)
+	case config.ODS:
+		result.Sheets, err = extractODS(body, d.cfg.HeaderRow)
+	default:
+		err = errUnknownDocumentFormat
+	}
+	if err != nil {
+		d.logger.Errorw("unable to extract document content", "format", string(format), "error", err.Error())
+		return "", nil, err
+	}
+
+	content, err := json.Marshal(result)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return url, content, nil
+}
+
+func detectDocumentFormat(body []byte) config.DocumentFormat {
+	if bytes.HasPrefix(body, []byte("%PDF")) {
+		return config.PDF
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	if err != nil {
+		return ""
+	}
+
+	for _, file := range archive.File {
+		switch file.Name {
+		case "xl/workbook.xml":
+			return config.XLSX
+		case "content.xml":
+			return config.ODS
+		}
+	}
+
+	return ""
+}
+
+func rowsWithHeader(rows [][]string, headerRow bool) []interface{} {
+	if !headerRow || len(rows) == 0 {
+		result := make([]interface{}, len(rows))
+		for i, row := range rows {
+			if row == nil {
+				row = []string{}
+			}
+			result[i] = row
+		}
+		return result
+	}
+
+	header := rows[0]
+	result := make([]interface{}, len(rows)-1)
+	for i, row := range rows[1:] {
+		kv := make(map[string]string, len(header))
+		for j, key := range header {
+			if key == "" {
+				continue
+			}
+			if j < len(row) {
+				kv[key] = row[j]
+			} else {
+				kv[key] = ""
+			}
+		}
+		result[i] = kv
+	}
+
+	return result
+}
+
+// WithDocument convert PDF/XLSX/ODS response of the original connector into JSON
+func WithDocument(original Connector, cfg *config.DocumentConfig) *documentConnector {
+	return &documentConnector{
+		original: original,
+		cfg:      cfg,
+		logger:   logger.Null,
+	}
+}
+
+func (d *documentConnector) WithLogger(logger logger.Logger) *documentConnector {
+	d.logger = logger
+	return d
+}
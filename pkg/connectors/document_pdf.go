@@ -0,0 +1,73 @@
+package connectors
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/ledongthuc/pdf"
+	"strings"
+)
+
+func extractPDF(body []byte) (pages []*documentPage, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			pages = nil
+			err = fmt.Errorf("unable to read pdf: %v", r)
+		}
+	}()
+
+	reader, err := pdf.NewReader(bytes.NewReader(body), int64(len(body)))
+	if err != nil {
+		return nil, err
+	}
+
+	fonts := make(map[string]*pdf.Font)
+	for i := 1; i <= reader.NumPage(); i++ {
+		page := reader.Page(i)
+		if page.V.IsNull() {
+			continue
+		}
+
+		for _, name := range page.Fonts() {
+			if _, ok := fonts[name]; !ok {
+				font := page.Font(name)
+				fonts[name] = &font
+			}
+		}
+
+		var lines []string
+		rows, errRows := page.GetTextByRow()
+		if errRows == nil {
+			for _, row := range rows {
+				var line strings.Builder
+				for _, word := range row.Content {
+					line.WriteString(word.S)
+				}
+				if text := strings.TrimSpace(line.String()); text != "" {
+					lines = append(lines, text)
+				}
+			}
+		}
+
+		text := strings.Join(lines, "\n")
+		if len(lines) == 0 {
+			plainText, errText := page.GetPlainText(fonts)
+			if errText != nil {
+				return nil, errText
+			}
+			text = strings.TrimSpace(plainText)
+			for _, line := range strings.Split(text, "\n") {
+				if line = strings.TrimSpace(line); line != "" {
+					lines = append(lines, line)
+				}
+			}
+		}
+
+		pages = append(pages, &documentPage{
+			Page:  i,
+			Text:  text,
+			Lines: lines,
+		})
+	}
+
+	return pages, nil
+}
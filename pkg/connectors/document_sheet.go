@@ -0,0 +1,364 @@
+package connectors
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"io/fs"
+	"path"
+	"strconv"
+	"strings"
+)
+
+const (
+	// maxSheetRepeat limit of the repeated rows and cells in ODS and of the rows and columns in XLSX
+	maxSheetRepeat = 10000
+	// maxSheetCells limit of the cells in one sheet
+	maxSheetCells = 1000000
+	// maxZipFileSize limit of the unpacked file inside XLSX and ODS archive, so zip bomb does not take all memory
+	maxZipFileSize = 100 << 20
+)
+
+var (
+	errZipFileTooLarge = errors.New("unpacked file of the document is too large")
+)
+
+type xlsxWorkbook struct {
+	Sheets []struct {
+		Name string `xml:"name,attr"`
+		ID   string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+type xlsxRelationships struct {
+	Relationships []struct {
+		ID     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+type xlsxRichText struct {
+	T string `xml:"t"`
+	R []struct {
+		T string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (x *xlsxRichText) String() string {
+	if len(x.R) == 0 {
+		return x.T
+	}
+
+	var sb strings.Builder
+	for _, r := range x.R {
+		sb.WriteString(r.T)
+	}
+	return sb.String()
+}
+
+type xlsxSharedStrings struct {
+	Items []*xlsxRichText `xml:"si"`
+}
+
+type xlsxWorksheet struct {
+	Rows []struct {
+		Cells []struct {
+			Ref    string        `xml:"r,attr"`
+			Type   string        `xml:"t,attr"`
+			Value  string        `xml:"v"`
+			Inline *xlsxRichText `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+type odsText string
+
+func (t *odsText) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var sb strings.Builder
+	depth := 0
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return err
+		}
+
+		switch v := token.(type) {
+		case xml.CharData:
+			sb.Write(v)
+		case xml.StartElement:
+			depth++
+			if v.Name.Local == "s" || v.Name.Local == "tab" {
+				sb.WriteString(" ")
+			}
+		case xml.EndElement:
+			if depth == 0 {
+				*t = odsText(sb.String())
+				return nil
+			}
+			depth--
+		}
+	}
+}
+
+type odsCell struct {
+	Repeated   int       `xml:"number-columns-repeated,attr"`
+	ValueType  string    `xml:"value-type,attr"`
+	Value      string    `xml:"value,attr"`
+	DateValue  string    `xml:"date-value,attr"`
+	TimeValue  string    `xml:"time-value,attr"`
+	BoolValue  string    `xml:"boolean-value,attr"`
+	Paragraphs []odsText `xml:"p"`
+}
+
+func (c *odsCell) String() string {
+	switch c.ValueType {
+	case "float", "percentage", "currency":
+		return c.Value
+	case "date":
+		return c.DateValue
+	case "time":
+		return c.TimeValue
+	case "boolean":
+		return c.BoolValue
+	}
+
+	paragraphs := make([]string, len(c.Paragraphs))
+	for i, p := range c.Paragraphs {
+		paragraphs[i] = string(p)
+	}
+	return strings.Join(paragraphs, "\n")
+}
+
+type odsRow struct {
+	Repeated int        `xml:"number-rows-repeated,attr"`
+	Cells    []*odsCell `xml:",any"`
+}
+
+type odsTable struct {
+	Name       string    `xml:"name,attr"`
+	HeaderRows []*odsRow `xml:"table-header-rows>table-row"`
+	Rows       []*odsRow `xml:"table-row"`
+}
+
+type odsContent struct {
+	Tables []*odsTable `xml:"body>spreadsheet>table"`
+}
+
+func readZipFile(archive *zip.Reader, name string) ([]byte, error) {
+	file, err := archive.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	content, err := io.ReadAll(io.LimitReader(file, maxZipFileSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(content) > maxZipFileSize {
+		return nil, errZipFileTooLarge
+	}
+
+	return content, nil
+}
+
+func unmarshalZipFile(archive *zip.Reader, name string, value any) error {
+	content, err := readZipFile(archive, name)
+	if err != nil {
+		return err
+	}
+
+	return xml.Unmarshal(content, value)
+}
+
+func xlsxColumnIndex(ref string) int {
+	index := 0
+	for _, ch := range ref {
+		if ch < 'A' || ch > 'Z' {
+			break
+		}
+		index = index*26 + int(ch-'A'+1)
+	}
+	return index - 1
+}
+
+func trimEmptyTail(row []string) []string {
+	for len(row) > 0 && row[len(row)-1] == "" {
+		row = row[:len(row)-1]
+	}
+	return row
+}
+
+func extractXLSX(body []byte, headerRow bool) ([]*documentSheet, error) {
+	archive, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	if err != nil {
+		return nil, err
+	}
+
+	workbook := &xlsxWorkbook{}
+	if err = unmarshalZipFile(archive, "xl/workbook.xml", workbook); err != nil {
+		return nil, err
+	}
+
+	relationships := &xlsxRelationships{}
+	if err = unmarshalZipFile(archive, "xl/_rels/workbook.xml.rels", relationships); err != nil {
+		return nil, err
+	}
+	targets := make(map[string]string)
+	for _, rel := range relationships.Relationships {
+		if strings.HasPrefix(rel.Target, "/") {
+			targets[rel.ID] = strings.TrimPrefix(rel.Target, "/")
+		} else {
+			targets[rel.ID] = path.Join("xl", rel.Target)
+		}
+	}
+
+	sharedStrings := &xlsxSharedStrings{}
+	if _, errStat := fs.Stat(archive, "xl/sharedStrings.xml"); errStat == nil {
+		if err = unmarshalZipFile(archive, "xl/sharedStrings.xml", sharedStrings); err != nil {
+			return nil, err
+		}
+	}
+
+	sheets := make([]*documentSheet, 0, len(workbook.Sheets))
+	for _, sheetRef := range workbook.Sheets {
+		target, ok := targets[sheetRef.ID]
+		if !ok {
+			continue
+		}
+
+		worksheet := &xlsxWorksheet{}
+		if err = unmarshalZipFile(archive, target, worksheet); err != nil {
+			return nil, err
+		}
+
+		var rows [][]string
+		cells := 0
+		for _, xlsxRow := range worksheet.Rows {
+			if len(rows) >= maxSheetRepeat {
+				break
+			}
+
+			var row []string
+			for _, cell := range xlsxRow.Cells {
+				if len(row) >= maxSheetRepeat {
+					break
+				}
+				if cell.Ref != "" {
+					column := xlsxColumnIndex(cell.Ref)
+					if column >= maxSheetRepeat {
+						break
+					}
+					for len(row) < column {
+						row = append(row, "")
+					}
+				}
+
+				value := cell.Value
+				switch cell.Type {
+				case "s":
+					sharedIndex, errIndex := strconv.Atoi(cell.Value)
+					if errIndex == nil && sharedIndex >= 0 && sharedIndex < len(sharedStrings.Items) {
+						value = sharedStrings.Items[sharedIndex].String()
+					}
+				case "inlineStr":
+					if cell.Inline != nil {
+						value = cell.Inline.String()
+					}
+				}
+				row = append(row, value)
+			}
+
+			row = trimEmptyTail(row)
+			if cells+len(row) > maxSheetCells {
+				break
+			}
+			cells += len(row)
+			rows = append(rows, row)
+		}
+
+		sheets = append(sheets, &documentSheet{
+			Name: sheetRef.Name,
+			Rows: rowsWithHeader(rows, headerRow),
+		})
+	}
+
+	return sheets, nil
+}
+
+func odsRows(table *odsTable) [][]string {
+	var rows [][]string
+	cells := 0
+	for _, odsRow := range append(table.HeaderRows, table.Rows...) {
+		if len(rows) >= maxSheetRepeat {
+			break
+		}
+
+		var row []string
+		for _, cell := range odsRow.Cells {
+			repeated := cell.Repeated
+			if repeated <= 0 {
+				repeated = 1
+			}
+			value := cell.String()
+			if value != "" && repeated > maxSheetRepeat {
+				repeated = maxSheetRepeat
+			}
+			if value == "" && repeated > maxSheetRepeat {
+				repeated = 1
+			}
+			for i := 0; i < repeated && len(row) < maxSheetRepeat; i++ {
+				row = append(row, value)
+			}
+		}
+		row = trimEmptyTail(row)
+
+		// empty rows are repeated too, otherwise index of the next rows is shifted
+		repeated := odsRow.Repeated
+		if repeated <= 0 {
+			repeated = 1
+		}
+		for i := 0; i < repeated && len(rows) < maxSheetRepeat; i++ {
+			if cells+len(row) > maxSheetCells {
+				return trimEmptyRows(rows)
+			}
+			cells += len(row)
+			rows = append(rows, row)
+		}
+	}
+
+	return trimEmptyRows(rows)
+}
+
+func trimEmptyRows(rows [][]string) [][]string {
+	for len(rows) > 0 && len(rows[len(rows)-1]) == 0 {
+		rows = rows[:len(rows)-1]
+	}
+	return rows
+}
+
+func extractODS(body []byte, headerRow bool) ([]*documentSheet, error) {
+	archive, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	if err != nil {
+		return nil, err
+	}
+
+	content := &odsContent{}
+	if err = unmarshalZipFile(archive, "content.xml", content); err != nil {
+		return nil, err
+	}
+
+	sheets := make([]*documentSheet, len(content.Tables))
+	for i, table := range content.Tables {
+		sheets[i] = &documentSheet{
+			Name: table.Name,
+			Rows: rowsWithHeader(odsRows(table), headerRow),
+		}
+	}
+
+	return sheets, nil
+}
@@ -0,0 +1,149 @@
+package connectors_test
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"github.com/PxyUp/fitter/pkg/config"
+	"github.com/PxyUp/fitter/pkg/connectors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path"
+	"strings"
+	"testing"
+)
+
+type documentResult struct {
+	Pages []struct {
+		Page  int      `json:"page"`
+		Text  string   `json:"text"`
+		Lines []string `json:"lines"`
+	} `json:"pages"`
+	Sheets []struct {
+		Name string            `json:"name"`
+		Rows []json.RawMessage `json:"rows"`
+	} `json:"sheets"`
+}
+
+func getDocument(t *testing.T, filePath string, cfg *config.DocumentConfig) *documentResult {
+	body, err := connectors.WithDocument(connectors.NewFile(&config.FileConnectorConfig{
+		Path: filePath,
+	}), cfg).Get(nil, nil, nil)
+	require.NoError(t, err)
+
+	result := &documentResult{}
+	require.NoError(t, json.Unmarshal(body, result))
+	return result
+}
+
+func TestDocument_PDF(t *testing.T) {
+	result := getDocument(t, "testdata/report.pdf", &config.DocumentConfig{})
+
+	require.Len(t, result.Pages, 2)
+	assert.Equal(t, 1, result.Pages[0].Page)
+	assert.Equal(t, []string{"Monthly report", "Total 42"}, result.Pages[0].Lines)
+	assert.Equal(t, "Monthly report\nTotal 42", result.Pages[0].Text)
+	assert.Equal(t, 2, result.Pages[1].Page)
+	assert.Equal(t, []string{"Second page"}, result.Pages[1].Lines)
+}
+
+func TestDocument_XLSX(t *testing.T) {
+	result := getDocument(t, "testdata/sheet.xlsx", &config.DocumentConfig{})
+
+	require.Len(t, result.Sheets, 1)
+	assert.Equal(t, "Prices", result.Sheets[0].Name)
+	require.Len(t, result.Sheets[0].Rows, 3)
+	assert.JSONEq(t, `["name", "price"]`, string(result.Sheets[0].Rows[0]))
+	assert.JSONEq(t, `["apple", "1.5"]`, string(result.Sheets[0].Rows[1]))
+	assert.JSONEq(t, `["banana", "", "extra"]`, string(result.Sheets[0].Rows[2]))
+
+	withHeader := getDocument(t, "testdata/sheet.xlsx", &config.DocumentConfig{
+		Format:    config.XLSX,
+		HeaderRow: true,
+	})
+	require.Len(t, withHeader.Sheets[0].Rows, 2)
+	assert.JSONEq(t, `{"name": "apple", "price": "1.5"}`, string(withHeader.Sheets[0].Rows[0]))
+	assert.JSONEq(t, `{"name": "banana", "price": ""}`, string(withHeader.Sheets[0].Rows[1]))
+}
+
+func TestDocument_ODS(t *testing.T) {
+	result := getDocument(t, "testdata/sheet.ods", &config.DocumentConfig{
+		HeaderRow: true,
+	})
+
+	require.Len(t, result.Sheets, 1)
+	assert.Equal(t, "Prices", result.Sheets[0].Name)
+	require.Len(t, result.Sheets[0].Rows, 2)
+	assert.JSONEq(t, `{"name": "apple", "price": "1.5"}`, string(result.Sheets[0].Rows[0]))
+	assert.JSONEq(t, `{"name": "apple", "price": "1.5"}`, string(result.Sheets[0].Rows[1]))
+}
+
+// writeZip create archive with files in the temp dir
+func writeZip(t *testing.T, name string, files map[string]string) string {
+	filePath := path.Join(t.TempDir(), name)
+	file, err := os.Create(filePath)
+	require.NoError(t, err)
+	archive := zip.NewWriter(file)
+	for fileName, content := range files {
+		writer, errCreate := archive.Create(fileName)
+		require.NoError(t, errCreate)
+		_, err = writer.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, archive.Close())
+	require.NoError(t, file.Close())
+	return filePath
+}
+
+func TestDocument_ODSRepeatedRows(t *testing.T) {
+	filePath := writeZip(t, "repeated.ods", map[string]string{
+		"content.xml": `<office:document-content xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0" xmlns:table="urn:oasis:names:tc:opendocument:xmlns:table:1.0" xmlns:text="urn:oasis:names:tc:opendocument:xmlns:text:1.0"><office:body><office:spreadsheet><table:table table:name="Gaps">` +
+			`<table:table-row><table:table-cell office:value-type="string"><text:p>first</text:p></table:table-cell></table:table-row>` +
+			`<table:table-row table:number-rows-repeated="2"><table:table-cell/></table:table-row>` +
+			`<table:table-row><table:table-cell office:value-type="string"><text:p>fourth</text:p></table:table-cell></table:table-row>` +
+			`<table:table-row table:number-rows-repeated="1048572"><table:table-cell table:number-columns-repeated="1024"/></table:table-row>` +
+			`</table:table></office:spreadsheet></office:body></office:document-content>`,
+	})
+
+	result := getDocument(t, filePath, &config.DocumentConfig{
+		Format: config.ODS,
+	})
+	require.Len(t, result.Sheets, 1)
+	require.Len(t, result.Sheets[0].Rows, 4)
+	assert.JSONEq(t, `["first"]`, string(result.Sheets[0].Rows[0]))
+	assert.JSONEq(t, `[]`, string(result.Sheets[0].Rows[1]))
+	assert.JSONEq(t, `[]`, string(result.Sheets[0].Rows[2]))
+	assert.JSONEq(t, `["fourth"]`, string(result.Sheets[0].Rows[3]))
+}
+
+func TestDocument_ZipBomb(t *testing.T) {
+	filePath := writeZip(t, "bomb.ods", map[string]string{
+		"content.xml": strings.Repeat(" ", 101<<20),
+	})
+
+	_, err := connectors.WithDocument(connectors.NewFile(&config.FileConnectorConfig{
+		Path: filePath,
+	}), &config.DocumentConfig{
+		Format: config.ODS,
+	}).Get(nil, nil, nil)
+	assert.ErrorContains(t, err, "too large")
+}
+
+func TestDocument_XLSXLimit(t *testing.T) {
+	var sheetData strings.Builder
+	for i := 1; i <= 10005; i++ {
+		sheetData.WriteString(fmt.Sprintf(`<row r="%d"><c r="A%d"><v>%d</v></c><c r="XFD%d"><v>far</v></c></row>`, i, i, i, i))
+	}
+
+	filePath := writeZip(t, "big.xlsx", map[string]string{
+		"xl/workbook.xml":            `<workbook xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="Big" r:id="rId1"/></sheets></workbook>`,
+		"xl/_rels/workbook.xml.rels": `<Relationships><Relationship Id="rId1" Target="worksheets/sheet1.xml"/></Relationships>`,
+		"xl/worksheets/sheet1.xml":   `<worksheet><sheetData>` + sheetData.String() + `</sheetData></worksheet>`,
+	})
+
+	result := getDocument(t, filePath, &config.DocumentConfig{})
+	require.Len(t, result.Sheets, 1)
+	assert.Len(t, result.Sheets[0].Rows, 10000)
+	assert.JSONEq(t, `["1"]`, string(result.Sheets[0].Rows[0]))
+}
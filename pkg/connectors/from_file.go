@@ -5,7 +5,6 @@ import (
 	"github.com/PxyUp/fitter/pkg/config"
 	"github.com/PxyUp/fitter/pkg/logger"
 	"github.com/PxyUp/fitter/pkg/utils"
-	"io"
 	"os"
 )
 
@@ -24,7 +23,7 @@ func (j *fileConnector) Get(parsedValue builder.Interfacable, index *uint32, inp
 		_ = file.Close()
 	}()
 
-	body, err := io.ReadAll(file)
+	body, err := utils.ReadAll(file, utils.BodyLimit(j.cfg.MaxBodySize))
 	if err != nil {
 		j.logger.Errorw("cant read file content", "error", err.Error())
 		return nil, err
@@ -0,0 +1,203 @@
+package connectors
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/PxyUp/fitter/pkg/builder"
+	"github.com/PxyUp/fitter/pkg/config"
+	"github.com/PxyUp/fitter/pkg/logger"
+	"github.com/PxyUp/fitter/pkg/utils"
+	"github.com/tidwall/gjson"
+	"net/http"
+	"strings"
+)
+
+const (
+	defaultGraphQLMaxPages       = 100
+	defaultGraphQLCursorVariable = "after"
+)
+
+var (
+	errGraphQL = errors.New("graphql errors")
+)
+
+type graphQLConnector struct {
+	cfg    *config.GraphQLConnectorConfig
+	api    *apiConnector
+	logger logger.Logger
+}
+
+type graphQLRequest struct {
+	Query         string                 `json:"query"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+	OperationName string                 `json:"operationName,omitempty"`
+}
+
+type graphQLError struct {
+	Message string `json:"message"`
+}
+
+type graphQLResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []graphQLError  `json:"errors"`
+}
+
+func NewGraphQL(url string, cfg *config.GraphQLConnectorConfig) *graphQLConnector {
+	serverCfg := config.ServerConnectorConfig{}
+	if cfg.Server != nil {
+		serverCfg = *cfg.Server
+	}
+	serverCfg.Method = http.MethodPost
+	serverCfg.Body = ""
+	serverCfg.Form = nil
+	serverCfg.Multipart = nil
+	serverCfg.JSON = nil
+
+	headers := make(map[string]string, len(serverCfg.Headers)+len(cfg.Headers))
+	for k, v := range serverCfg.Headers {
+		headers[k] = v
+	}
+	for k, v := range cfg.Headers {
+		headers[k] = v
+	}
+	serverCfg.Headers = headers
+
+	return &graphQLConnector{
+		cfg:    cfg,
+		api:    NewAPI(url, &serverCfg, nil),
+		logger: logger.Null,
+	}
+}
+
+func (g *graphQLConnector) WithLogger(logger logger.Logger) *graphQLConnector {
+	g.logger = logger
+	g.api.WithLogger(logger)
+	return g
+}
+
+func (g *graphQLConnector) Get(parsedValue builder.Interfacable, index *uint32, input builder.Interfacable) ([]byte, error) {
+	variables := make(map[string]interface{})
+	if len(g.cfg.Variables) > 0 {
+		err := json.Unmarshal(g.cfg.Variables, &variables)
+		if err != nil {
+			g.logger.Errorw("unable to parse graphql variables", "error", err.Error())
+			return nil, err
+		}
+		for k, v := range variables {
+			variables[k] = formatVariable(v, parsedValue, index, input)
+		}
+	}
+
+	if g.cfg.Pagination == nil {
+		return g.query(variables, parsedValue, index, input)
+	}
+
+	return g.paginate(variables, parsedValue, index, input)
+}
+
+// formatVariable format string values of the variable, so injected values are escaped on marshaling.
+// String which is exactly one placeholder is replaced with json value of the result(number, boolean, object), so not only string variables can be injected
+func formatVariable(value interface{}, parsedValue builder.Interfacable, index *uint32, input builder.Interfacable) interface{} {
+	switch v := value.(type) {
+	case string:
+		formatted := utils.Format(v, parsedValue, index, input)
+		if utils.IsPlaceholder(v) {
+			var typed interface{}
+			if err := json.Unmarshal([]byte(formatted), &typed); err == nil {
+				return typed
+			}
+		}
+		return formatted
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = formatVariable(item, parsedValue, index, input)
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = formatVariable(item, parsedValue, index, input)
+		}
+		return v
+	}
+
+	return value
+}
+
+func (g *graphQLConnector) query(variables map[string]interface{}, parsedValue builder.Interfacable, index *uint32, input builder.Interfacable) ([]byte, error) {
+	requestBody, err := json.Marshal(&graphQLRequest{
+		Query:         g.cfg.Query,
+		Variables:     variables,
+		OperationName: g.cfg.OperationName,
+	})
+	if err != nil {
+		g.logger.Errorw("unable to create graphql request", "error", err.Error())
+		return nil, err
+	}
+
+	_, body, err := g.api.getWithBody(&payload{
+		content:     requestBody,
+		contentType: jsonContentType,
+	}, parsedValue, index, input)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp graphQLResponse
+	if err = json.Unmarshal(body, &resp); err != nil {
+		g.logger.Errorw("unable to parse graphql response", "error", err.Error())
+		return nil, err
+	}
+
+	if len(resp.Errors) > 0 {
+		messages := make([]string, len(resp.Errors))
+		for i, graphErr := range resp.Errors {
+			messages[i] = graphErr.Message
+		}
+		errGraphQLResponse := fmt.Errorf("%w: %s", errGraphQL, strings.Join(messages, "; "))
+		g.logger.Errorw("graphql response contains errors", "error", errGraphQLResponse.Error())
+
+		if !g.cfg.IgnoreErrors || len(resp.Data) == 0 || string(resp.Data) == builder.NullValue.ToJson() {
+			return nil, errGraphQLResponse
+		}
+	}
+
+	return resp.Data, nil
+}
+
+// paginate fetch pages while pageInfo.hasNextPage is true and concatenate items of all pages
+func (g *graphQLConnector) paginate(variables map[string]interface{}, parsedValue builder.Interfacable, index *uint32, input builder.Interfacable) ([]byte, error) {
+	maxPages := uint32(defaultGraphQLMaxPages)
+	if g.cfg.Pagination.MaxPages > 0 {
+		maxPages = g.cfg.Pagination.MaxPages
+	}
+
+	cursorVariable := defaultGraphQLCursorVariable
+	if g.cfg.Pagination.CursorVariable != "" {
+		cursorVariable = g.cfg.Pagination.CursorVariable
+	}
+
+	items := make([]json.RawMessage, 0)
+	for page := uint32(0); page < maxPages; page++ {
+		data, err := g.query(variables, parsedValue, index, input)
+		if err != nil {
+			return nil, err
+		}
+
+		result := gjson.ParseBytes(data)
+		for _, item := range result.Get(g.cfg.Pagination.ItemsPath).Array() {
+			items = append(items, json.RawMessage(item.Raw))
+		}
+
+		pageInfo := result.Get(g.cfg.Pagination.PageInfoPath)
+		cursor := pageInfo.Get("endCursor")
+		if !pageInfo.Get("hasNextPage").Bool() || !cursor.Exists() || cursor.Type == gjson.Null {
+			break
+		}
+
+		g.logger.Debugw("fetch next graphql page", "page", fmt.Sprintf("%d", page+1), "cursor", cursor.String())
+		variables[cursorVariable] = cursor.Value()
+	}
+
+	return json.Marshal(items)
+}
@@ -0,0 +1,86 @@
+package connectors_test
+
+import (
+	"encoding/json"
+	"github.com/PxyUp/fitter/pkg/builder"
+	"github.com/PxyUp/fitter/pkg/config"
+	"github.com/PxyUp/fitter/pkg/connectors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGraphQL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Query         string                 `json:"query"`
+			Variables     map[string]interface{} `json:"variables"`
+			OperationName string                 `json:"operationName"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if req.Variables["owner"] != "fitter" || req.OperationName != "Repos" || r.Header.Get("X-Token") != "secret" {
+			_, _ = w.Write([]byte(`{"data": null, "errors": [{"message": "bad request"}, {"message": "owner not found"}]}`))
+			return
+		}
+		if req.Variables["after"] == "first" {
+			_, _ = w.Write([]byte(`{"data": {"repos": {"nodes": [{"name": "third"}], "pageInfo": {"hasNextPage": false, "endCursor": null}}}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data": {"repos": {"nodes": [{"name": "first"}, {"name": "second"}], "pageInfo": {"hasNextPage": true, "endCursor": "first"}}}}`))
+	}))
+	defer server.Close()
+
+	graphQLConfig := &config.GraphQLConnectorConfig{
+		Query:         `query Repos($owner: String!, $after: String) { repos(owner: $owner, after: $after) { nodes { name } pageInfo { hasNextPage endCursor } } }`,
+		Variables:     json.RawMessage(`{"owner": "{PL}"}`),
+		OperationName: "Repos",
+		Headers: map[string]string{
+			"X-Token": "secret",
+		},
+		Pagination: &config.GraphQLPaginationConfig{
+			PageInfoPath: "repos.pageInfo",
+			ItemsPath:    "repos.nodes",
+		},
+	}
+
+	body, err := connectors.NewGraphQL(server.URL, graphQLConfig).Get(builder.PureString("fitter"), nil, nil)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"name": "first"}, {"name": "second"}, {"name": "third"}]`, string(body))
+
+	_, err = connectors.NewGraphQL(server.URL, graphQLConfig).Get(builder.PureString("unknown"), nil, nil)
+	assert.ErrorContains(t, err, "bad request; owner not found")
+}
+
+func TestGraphQL_VariablesEscaping(t *testing.T) {
+	var variables json.RawMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Variables json.RawMessage `json:"variables"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		variables = req.Variables
+		_, _ = w.Write([]byte(`{"data": {"ok": true}}`))
+	}))
+	defer server.Close()
+
+	index := uint32(1)
+	_, err := connectors.NewGraphQL(server.URL, &config.GraphQLConnectorConfig{
+		Query:     `query Search($text: String!) { ok }`,
+		Variables: json.RawMessage(`{"text": "say {PL}", "filter": {"names": ["{PL}"], "page": 2, "index": "{INDEX}"}}`),
+	}).Get(builder.PureString(`a "quoted" \ value`), &index, nil)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"text": "say a \"quoted\" \\ value", "filter": {"names": ["a \"quoted\" \\ value"], "page": 2, "index": 1}}`, string(variables))
+
+	_, err = connectors.NewGraphQL(server.URL, &config.GraphQLConnectorConfig{
+		Query:     `query Search($first: Int!, $active: Boolean, $name: String) { ok }`,
+		Variables: json.RawMessage(`{"first": "{{{limit}}}", "active": "{{{active}}}", "name": "{{{name}}}", "text": "limit {{{limit}}}"}`),
+	}).Get(builder.Object(map[string]builder.Interfacable{
+		"limit":  builder.Number(20),
+		"active": builder.Bool(true),
+		"name":   builder.String("fitter"),
+	}), nil, nil)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"first": 20, "active": true, "name": "fitter", "text": "limit 20"}`, string(variables))
+}
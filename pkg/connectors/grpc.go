@@ -0,0 +1,303 @@
+package connectors
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/PxyUp/fitter/pkg/builder"
+	"github.com/PxyUp/fitter/pkg/config"
+	"github.com/PxyUp/fitter/pkg/http_client"
+	"github.com/PxyUp/fitter/pkg/logger"
+	"github.com/PxyUp/fitter/pkg/utils"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	errGRPCInvalidMethod  = errors.New("invalid grpc method, expected package.Service/Method")
+	errGRPCMethodNotFound = errors.New("grpc method not found")
+	errGRPCReflection     = errors.New("grpc reflection error")
+
+	grpcConns     = make(map[string]*grpc.ClientConn)
+	grpcConnMutex sync.Mutex
+)
+
+type grpcConnector struct {
+	cfg    *config.GRPCConnectorConfig
+	logger logger.Logger
+
+	mutex   sync.Mutex
+	methods map[string]protoreflect.MethodDescriptor
+}
+
+func NewGRPC(cfg *config.GRPCConnectorConfig) *grpcConnector {
+	return &grpcConnector{
+		cfg:     cfg,
+		logger:  logger.Null,
+		methods: make(map[string]protoreflect.MethodDescriptor),
+	}
+}
+
+func (g *grpcConnector) WithLogger(logger logger.Logger) *grpcConnector {
+	g.logger = logger
+	return g
+}
+
+func (g *grpcConnector) Get(parsedValue builder.Interfacable, index *uint32, input builder.Interfacable) ([]byte, error) {
+	target := utils.Format(g.cfg.Target, parsedValue, index, input)
+	if target == "" {
+		return nil, errEmpty
+	}
+
+	service, method, err := splitGRPCMethod(g.cfg.Method)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := grpcConn(target, g.cfg.TLS)
+	if err != nil {
+		g.logger.Errorw("unable to create grpc connection", "target", target, "error", err.Error())
+		return nil, err
+	}
+
+	tt := timeout
+	if g.cfg.Timeout > 0 {
+		tt = time.Duration(g.cfg.Timeout) * time.Second
+	}
+	reqCtx, cancel := context.WithTimeout(ctx, tt)
+	defer cancel()
+
+	if len(g.cfg.Metadata) > 0 {
+		reqCtx = metadata.NewOutgoingContext(reqCtx, metadata.New(formatHeaders(g.cfg.Metadata, parsedValue, index, input)))
+	}
+
+	methodDesc, err := g.methodDescriptor(reqCtx, conn, target, service, method)
+	if err != nil {
+		g.logger.Errorw("unable to resolve grpc method", "target", target, "method", g.cfg.Method, "error", err.Error())
+		return nil, err
+	}
+
+	req := dynamicpb.NewMessage(methodDesc.Input())
+	body, err := g.body(parsedValue, index, input)
+	if err != nil {
+		g.logger.Errorw("unable to parse grpc body", "error", err.Error())
+		return nil, err
+	}
+	if len(body) > 0 {
+		if err = protojson.Unmarshal(body, req); err != nil {
+			g.logger.Errorw("unable to create grpc request", "body", string(body), "error", err.Error())
+			return nil, err
+		}
+	}
+
+	g.logger.Infow("sending grpc request", "target", target, "method", g.cfg.Method, "body", string(body))
+	resp := dynamicpb.NewMessage(methodDesc.Output())
+	if err = conn.Invoke(reqCtx, "/"+service+"/"+method, req, resp); err != nil {
+		g.logger.Errorw("unable to send grpc request", "target", target, "method", g.cfg.Method, "error", err.Error())
+		return nil, err
+	}
+
+	return protojson.MarshalOptions{
+		EmitUnpopulated: true,
+		UseProtoNames:   g.cfg.UseProtoNames,
+	}.Marshal(resp)
+}
+
+// body format string values of the request body after parsing, so injected values are escaped like graphql variables
+func (g *grpcConnector) body(parsedValue builder.Interfacable, index *uint32, input builder.Interfacable) ([]byte, error) {
+	if len(g.cfg.Body) == 0 {
+		return nil, nil
+	}
+
+	var body interface{}
+	decoder := json.NewDecoder(bytes.NewReader(g.cfg.Body))
+	// int64 fields are kept without float rounding
+	decoder.UseNumber()
+	if err := decoder.Decode(&body); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(formatVariable(body, parsedValue, index, input))
+}
+
+// methodDescriptor resolve method from descriptor set file or server reflection, result is cached per target
+func (g *grpcConnector) methodDescriptor(ctx context.Context, conn *grpc.ClientConn, target string, service string, method string) (protoreflect.MethodDescriptor, error) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	key := target
+	if g.cfg.DescriptorSetFile != "" {
+		key = ""
+	}
+	if methodDesc, ok := g.methods[key]; ok {
+		return methodDesc, nil
+	}
+
+	var files *protoregistry.Files
+	var err error
+	if g.cfg.DescriptorSetFile != "" {
+		files, err = filesFromDescriptorSet(g.cfg.DescriptorSetFile)
+	} else {
+		files, err = filesFromReflection(ctx, conn, service)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	desc, err := files.FindDescriptorByName(protoreflect.FullName(service))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", errGRPCMethodNotFound, err.Error())
+	}
+	serviceDesc, ok := desc.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s is not a service", errGRPCMethodNotFound, service)
+	}
+	methodDesc := serviceDesc.Methods().ByName(protoreflect.Name(method))
+	if methodDesc == nil {
+		return nil, fmt.Errorf("%w: %s/%s", errGRPCMethodNotFound, service, method)
+	}
+
+	g.methods[key] = methodDesc
+	return methodDesc, nil
+}
+
+func splitGRPCMethod(fullMethod string) (string, string, error) {
+	service, method, ok := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	if !ok || service == "" || method == "" {
+		return "", "", errGRPCInvalidMethod
+	}
+	return service, method, nil
+}
+
+// grpcConn return shared connection for target and TLS settings
+func grpcConn(target string, tlsCfg *config.TLSConfig) (*grpc.ClientConn, error) {
+	tlsKey, _ := json.Marshal(tlsCfg)
+	key := target + "|" + string(tlsKey)
+
+	grpcConnMutex.Lock()
+	defer grpcConnMutex.Unlock()
+
+	if conn, ok := grpcConns[key]; ok {
+		return conn, nil
+	}
+
+	creds := insecure.NewCredentials()
+	if tlsCfg != nil {
+		tlsClientConfig, err := http_client.NewTLSConfig(tlsCfg)
+		if err != nil {
+			return nil, err
+		}
+		creds = credentials.NewTLS(tlsClientConfig)
+	}
+
+	conn, err := grpc.NewClient(target, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, err
+	}
+
+	grpcConns[key] = conn
+	return conn, nil
+}
+
+func filesFromDescriptorSet(path string) (*protoregistry.Files, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var set descriptorpb.FileDescriptorSet
+	if err = proto.Unmarshal(content, &set); err != nil {
+		return nil, err
+	}
+
+	return protodesc.NewFiles(&set)
+}
+
+// filesFromReflection fetch file of the service and all dependencies of it using server reflection
+func filesFromReflection(ctx context.Context, conn *grpc.ClientConn, service string) (*protoregistry.Files, error) {
+	stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = stream.CloseSend()
+	}()
+
+	known := make(map[string]*descriptorpb.FileDescriptorProto)
+	var order []string
+
+	request := &reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_FileContainingSymbol{
+			FileContainingSymbol: service,
+		},
+	}
+	var pending []string
+	for request != nil {
+		if err = stream.Send(request); err != nil {
+			return nil, err
+		}
+		resp, errRecv := stream.Recv()
+		if errRecv != nil {
+			return nil, errRecv
+		}
+		if errResp := resp.GetErrorResponse(); errResp != nil {
+			return nil, fmt.Errorf("%w: %s", errGRPCReflection, errResp.GetErrorMessage())
+		}
+
+		for _, raw := range resp.GetFileDescriptorResponse().GetFileDescriptorProto() {
+			var file descriptorpb.FileDescriptorProto
+			if err = proto.Unmarshal(raw, &file); err != nil {
+				return nil, err
+			}
+			if _, ok := known[file.GetName()]; ok {
+				continue
+			}
+			known[file.GetName()] = &file
+			order = append(order, file.GetName())
+			pending = append(pending, file.GetDependency()...)
+		}
+
+		request = nil
+		for len(pending) > 0 && request == nil {
+			dependency := pending[0]
+			pending = pending[1:]
+			if _, ok := known[dependency]; ok {
+				continue
+			}
+			if globalFile, errFind := protoregistry.GlobalFiles.FindFileByPath(dependency); errFind == nil {
+				known[dependency] = protodesc.ToFileDescriptorProto(globalFile)
+				order = append(order, dependency)
+				pending = append(pending, known[dependency].GetDependency()...)
+				continue
+			}
+			request = &reflectionpb.ServerReflectionRequest{
+				MessageRequest: &reflectionpb.ServerReflectionRequest_FileByFilename{
+					FileByFilename: dependency,
+				},
+			}
+		}
+	}
+
+	set := &descriptorpb.FileDescriptorSet{}
+	for _, name := range order {
+		set.File = append(set.File, known[name])
+	}
+
+	return protodesc.NewFiles(set)
+}
@@ -0,0 +1,80 @@
+package connectors_test
+
+import (
+	"encoding/json"
+	"github.com/PxyUp/fitter/pkg/builder"
+	"github.com/PxyUp/fitter/pkg/config"
+	"github.com/PxyUp/fitter/pkg/connectors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"net"
+	"os"
+	"path"
+	"testing"
+)
+
+func TestGRPC(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("fitter", healthpb.HealthCheckResponse_NOT_SERVING)
+	healthServer.SetServingStatus("api", healthpb.HealthCheckResponse_SERVING)
+	healthServer.SetServingStatus(`api", "unknown": "value`, healthpb.HealthCheckResponse_SERVING)
+	server := grpc.NewServer()
+	healthpb.RegisterHealthServer(server, healthServer)
+	reflection.Register(server)
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	defer server.Stop()
+
+	descriptorSet, err := proto.Marshal(&descriptorpb.FileDescriptorSet{
+		File: []*descriptorpb.FileDescriptorProto{protodesc.ToFileDescriptorProto(healthpb.File_grpc_health_v1_health_proto)},
+	})
+	require.NoError(t, err)
+	descriptorSetFile := path.Join(t.TempDir(), "health.pb")
+	require.NoError(t, os.WriteFile(descriptorSetFile, descriptorSet, 0600))
+
+	for _, descriptorSetPath := range []string{"", descriptorSetFile} {
+		connector := connectors.NewGRPC(&config.GRPCConnectorConfig{
+			Target:            listener.Addr().String(),
+			Method:            "grpc.health.v1.Health/Check",
+			Body:              json.RawMessage(`{"service": "{PL}"}`),
+			DescriptorSetFile: descriptorSetPath,
+		})
+
+		body, err := connector.Get(builder.String("fitter"), nil, nil)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"status": "NOT_SERVING"}`, string(body))
+
+		body, err = connector.Get(builder.String("api"), nil, nil)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"status": "SERVING"}`, string(body))
+
+		// injected value is escaped and can not add fields into request
+		body, err = connector.Get(builder.PureString(`api", "unknown": "value`), nil, nil)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"status": "SERVING"}`, string(body))
+	}
+
+	_, err = connectors.NewGRPC(&config.GRPCConnectorConfig{
+		Target: listener.Addr().String(),
+		Method: "grpc.health.v1.Health/Unknown",
+	}).Get(nil, nil, nil)
+	assert.Error(t, err)
+
+	_, err = connectors.NewGRPC(&config.GRPCConnectorConfig{
+		Target: listener.Addr().String(),
+		Method: "grpc.health.v1.Health/Check",
+		Body:   json.RawMessage(`{"service": {PL}}`),
+	}).Get(builder.String("api"), nil, nil)
+	assert.Error(t, err)
+}
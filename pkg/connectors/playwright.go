@@ -3,16 +3,19 @@ package connectors
 import (
 	"context"
 	"errors"
+	"github.com/PxyUp/fitter/pkg/auth"
 	"github.com/PxyUp/fitter/pkg/builder"
 	"github.com/PxyUp/fitter/pkg/config"
 	"github.com/PxyUp/fitter/pkg/limitter"
 	"github.com/PxyUp/fitter/pkg/logger"
+	"github.com/PxyUp/fitter/pkg/proxy"
 	"github.com/PxyUp/fitter/pkg/utils"
 	stealth "github.com/go-rod/stealth"
 	stealth2 "github.com/jonfriesen/playwright-go-stealth"
 	"github.com/playwright-community/playwright-go"
 	"go.uber.org/atomic"
 	"golang.org/x/sync/semaphore"
+	"strings"
 	"time"
 )
 
@@ -22,12 +25,12 @@ var (
 	errNoDriver          = errors.New("empty playwright driver")
 )
 
-func getFromPlaywright(url string, cfg *config.PlaywrightConfig, parsedValue builder.Interfacable, index *uint32, input builder.Interfacable, logger logger.Logger) ([]byte, error) {
+func getFromPlaywright(url string, cfg *config.PlaywrightConfig, parsedValue builder.Interfacable, index *uint32, input builder.Interfacable, logger logger.Logger) (string, []byte, error) {
 	if instanceLimit := limitter.PlaywrightLimiter(); instanceLimit != nil {
 		errInstance := instanceLimit.Acquire(ctx, 1)
 		if errInstance != nil {
 			logger.Errorw("unable to acquire playwright limit semaphore", "url", url, "error", errInstance.Error())
-			return nil, errInstance
+			return "", nil, errInstance
 		}
 		defer instanceLimit.Release(1)
 	}
@@ -43,7 +46,10 @@ func getFromPlaywright(url string, cfg *config.PlaywrightConfig, parsedValue bui
 	res := make(chan struct{})
 
 	var content string
+	var pageURL string
 	var err error
+	var pool *proxy.Pool
+	var pooledProxy *proxy.Proxy
 
 	go func() {
 		defer close(res)
@@ -90,20 +96,37 @@ func getFromPlaywright(url string, cfg *config.PlaywrightConfig, parsedValue bui
 
 		var opts []playwright.BrowserTypeLaunchOptions
 
-		if cfg.Proxy != nil {
-			proxy := &playwright.Proxy{}
-			if cfg.Proxy.Server != "" {
-				proxy.Server = utils.Format(cfg.Proxy.Server, parsedValue, index, input)
+		proxyCfg := cfg.Proxy
+		if proxyCfg == nil && cfg.ProxyPool != "" {
+			pool = proxy.Get(cfg.ProxyPool)
+			if pool == nil {
+				err = errProxyPoolNotFound
+				logger.Errorw("unable to find proxy pool", "proxy_pool", cfg.ProxyPool)
+				return
+			}
+
+			pooledProxy, err = pool.Pick(hostOf(url))
+			if err != nil {
+				logger.Errorw("unable to pick proxy from pool", "proxy_pool", cfg.ProxyPool, "error", err.Error())
+				return
+			}
+			proxyCfg = pooledProxy.ProxyConfig
+		}
+
+		if proxyCfg != nil {
+			playwrightProxy := &playwright.Proxy{}
+			if proxyCfg.Server != "" {
+				playwrightProxy.Server = utils.Format(proxyCfg.Server, parsedValue, index, input)
 			}
-			if cfg.Proxy.Username != "" {
-				proxy.Username = utils.String(utils.Format(cfg.Proxy.Username, parsedValue, index, input))
+			if proxyCfg.Username != "" {
+				playwrightProxy.Username = utils.String(utils.Format(proxyCfg.Username, parsedValue, index, input))
 			}
-			if cfg.Proxy.Password != "" {
-				proxy.Password = utils.String(utils.Format(cfg.Proxy.Password, parsedValue, index, input))
+			if proxyCfg.Password != "" {
+				playwrightProxy.Password = utils.String(utils.Format(proxyCfg.Password, parsedValue, index, input))
 			}
-			logger.Debugw("set proxy", "server", cfg.Proxy.Server, "username", cfg.Proxy.Username, "password", cfg.Proxy.Password)
+			logger.Debugw("set proxy", "server", proxyCfg.Server, "username", proxyCfg.Username, "password", proxyCfg.Password)
 			opts = append(opts, playwright.BrowserTypeLaunchOptions{
-				Proxy: proxy,
+				Proxy: playwrightProxy,
 			})
 		}
 
@@ -143,12 +166,31 @@ func getFromPlaywright(url string, cfg *config.PlaywrightConfig, parsedValue bui
 			}
 		}()
 
-		page, err := browserInstance.NewPage()
+		var pageOpts []playwright.BrowserNewPageOptions
+		var authHeaders map[string]string
+		origin := originOf(url)
+		if cfg.Auth != nil {
+			pageOpts, authHeaders, err = playwrightAuthOptions(cfg.Auth, origin, logger.With("component", "auth"))
+			if err != nil {
+				logger.Errorw("could not prepare auth for page", "error", err.Error())
+				return
+			}
+		}
+
+		page, err := browserInstance.NewPage(pageOpts...)
 		if err != nil {
 			logger.Errorw("could not create page: %v", "error", err.Error())
 			return
 		}
 
+		if len(authHeaders) > 0 {
+			err = routeAuthHeaders(page, origin, authHeaders)
+			if err != nil {
+				logger.Errorw("could not set auth headers for page", "error", err.Error())
+				return
+			}
+		}
+
 		if cfg.Stealth {
 			err = stealth2.Inject(page)
 			if err != nil {
@@ -178,10 +220,17 @@ func getFromPlaywright(url string, cfg *config.PlaywrightConfig, parsedValue bui
 		}
 
 		logger.Infof("going to url: %s", url)
-		_, err = page.Goto(url, playwright.PageGotoOptions{
+		response, err := page.Goto(url, playwright.PageGotoOptions{
 			Timeout:   playwright.Float(float64(tt.Milliseconds())),
 			WaitUntil: cfg.TypeOfWait,
 		})
+		if pool != nil {
+			statusCode := 0
+			if response != nil {
+				statusCode = response.Status()
+			}
+			pool.Report(pooledProxy, statusCode, err)
+		}
 		if err != nil {
 			logger.Errorw("could not goto", "error", err.Error())
 			return
@@ -200,12 +249,50 @@ func getFromPlaywright(url string, cfg *config.PlaywrightConfig, parsedValue bui
 			logger.Errorw("unable to get page content", "error", err.Error())
 			return
 		}
+		pageURL = page.URL()
 	}()
 
 	select {
 	case <-res:
-		return []byte(content), err
+		return pageURL, []byte(content), err
 	case <-ctxT.Done():
-		return nil, ctxT.Err()
+		return "", nil, ctxT.Err()
+	}
+}
+
+// playwrightAuthOptions return page options with http credentials or auth headers, both are limited to origin of the target url
+func playwrightAuthOptions(cfg *config.AuthConfig, origin string, logger logger.Logger) ([]playwright.BrowserNewPageOptions, map[string]string, error) {
+	if username, password, ok := auth.Credentials(cfg); ok {
+		return []playwright.BrowserNewPageOptions{
+			{
+				HttpCredentials: &playwright.HttpCredentials{
+					Username: username,
+					Password: password,
+					Origin:   playwright.String(origin),
+				},
+			},
+		}, nil, nil
 	}
+
+	headers, err := auth.Headers(cfg, logger)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return nil, headers, nil
+}
+
+// routeAuthHeaders add auth headers only to requests of the origin, so third-party resources of the page do not get them
+func routeAuthHeaders(page playwright.Page, origin string, authHeaders map[string]string) error {
+	return page.Route(func(requestURL string) bool {
+		return originOf(requestURL) == origin
+	}, func(route playwright.Route) {
+		headers := route.Request().Headers()
+		for k, v := range authHeaders {
+			headers[strings.ToLower(k)] = v
+		}
+		_ = route.Continue(playwright.RouteContinueOptions{
+			Headers: headers,
+		})
+	})
 }
@@ -0,0 +1,218 @@
+package connectors
+
+import (
+	"bytes"
+	"errors"
+	"github.com/PxyUp/fitter/pkg/builder"
+	"github.com/PxyUp/fitter/pkg/config"
+	"github.com/PxyUp/fitter/pkg/logger"
+	"github.com/PxyUp/fitter/pkg/utils"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	formContentType = "application/x-www-form-urlencoded"
+	jsonContentType = "application/json"
+)
+
+var (
+	errObjectBuilderMissing = errors.New("object builder is not set")
+
+	quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+)
+
+// ObjectBuilder build object from fields with parsed value as source
+type ObjectBuilder func(cfg *config.ObjectConfig, parsedValue builder.Interfacable, index *uint32, input builder.Interfacable, logger logger.Logger) builder.Interfacable
+
+// payload is body of the request, multipart body is streamed from files on each open
+type payload struct {
+	content     []byte
+	contentType string
+	stream      func() io.ReadCloser
+}
+
+// attach set body of the request, it is called right before request is sent, so streamed body(opened files and writing goroutine) is not leaked for not sent request
+func (p *payload) attach(req *http.Request) {
+	if p.stream != nil {
+		req.Body = p.stream()
+		// streamed body is written again on redirect
+		req.GetBody = func() (io.ReadCloser, error) {
+			return p.stream(), nil
+		}
+		return
+	}
+
+	if len(p.content) == 0 {
+		return
+	}
+
+	req.ContentLength = int64(len(p.content))
+	req.Body = io.NopCloser(bytes.NewReader(p.content))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(p.content)), nil
+	}
+}
+
+// String return body for logs, streamed body is not logged
+func (p *payload) String() string {
+	if p.stream != nil {
+		return p.contentType
+	}
+	return string(p.content)
+}
+
+// WithObjectBuilder set builder of the JSON request body, connectors can not depend on parser directly
+func (api *apiConnector) WithObjectBuilder(b ObjectBuilder) *apiConnector {
+	api.objectBuilder = b
+	return api
+}
+
+// requestBody return body of the request, content type is empty for raw Body
+func (api *apiConnector) requestBody(parsedValue builder.Interfacable, index *uint32, input builder.Interfacable) (*payload, error) {
+	if api.cfg.JSON != nil {
+		if api.objectBuilder == nil {
+			return nil, errObjectBuilderMissing
+		}
+		// Raw is used because ToJson escape html entities in strings
+		return &payload{
+			content:     api.objectBuilder(api.cfg.JSON, parsedValue, index, input, api.logger).Raw(),
+			contentType: jsonContentType,
+		}, nil
+	}
+
+	if len(api.cfg.Multipart) > 0 {
+		return multipartBody(api.cfg.Multipart, parsedValue, index, input)
+	}
+
+	if len(api.cfg.Form) > 0 {
+		values := make(url.Values, len(api.cfg.Form))
+		for k, v := range api.cfg.Form {
+			values.Set(k, utils.Format(v, parsedValue, index, input))
+		}
+		return &payload{
+			content:     []byte(values.Encode()),
+			contentType: formContentType,
+		}, nil
+	}
+
+	return &payload{
+		content: []byte(utils.Format(api.cfg.Body, parsedValue, index, input)),
+	}, nil
+}
+
+// multipartPart is part of the multipart body with formatted values
+type multipartPart struct {
+	name        string
+	value       string
+	filePath    string
+	fileName    string
+	contentType string
+}
+
+// multipartBody stream files into the body without reading them into memory, missing files are reported before request is sent
+func multipartBody(parts []*config.MultipartPart, parsedValue builder.Interfacable, index *uint32, input builder.Interfacable) (*payload, error) {
+	formatted := make([]*multipartPart, len(parts))
+	for i, part := range parts {
+		formatted[i] = &multipartPart{
+			name:        utils.Format(part.Name, parsedValue, index, input),
+			contentType: part.ContentType,
+		}
+		if part.File == "" {
+			formatted[i].value = utils.Format(part.Value, parsedValue, index, input)
+			continue
+		}
+
+		formatted[i].filePath = utils.Format(part.File, parsedValue, index, input)
+		if _, err := os.Stat(formatted[i].filePath); err != nil {
+			return nil, err
+		}
+		formatted[i].fileName = filepath.Base(formatted[i].filePath)
+		if part.FileName != "" {
+			formatted[i].fileName = utils.Format(part.FileName, parsedValue, index, input)
+		}
+	}
+
+	boundary := multipart.NewWriter(io.Discard).Boundary()
+	return &payload{
+		contentType: "multipart/form-data; boundary=" + boundary,
+		stream: func() io.ReadCloser {
+			reader, writer := io.Pipe()
+			go func() {
+				_ = writer.CloseWithError(writeMultipart(writer, boundary, formatted))
+			}()
+			return reader
+		},
+	}, nil
+}
+
+func writeMultipart(body io.Writer, boundary string, parts []*multipartPart) error {
+	writer := multipart.NewWriter(body)
+	if err := writer.SetBoundary(boundary); err != nil {
+		return err
+	}
+
+	for _, part := range parts {
+		if part.filePath == "" {
+			if err := writeValuePart(writer, part.name, part.value, part.contentType); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := writeFilePart(writer, part.name, part.filePath, part.fileName, part.contentType); err != nil {
+			return err
+		}
+	}
+
+	return writer.Close()
+}
+
+func writeValuePart(writer *multipart.Writer, name string, value string, contentType string) error {
+	if contentType == "" {
+		return writer.WriteField(name, value)
+	}
+
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", `form-data; name="`+escapeQuotes(name)+`"`)
+	header.Set("Content-Type", contentType)
+	partWriter, err := writer.CreatePart(header)
+	if err != nil {
+		return err
+	}
+	_, err = partWriter.Write([]byte(value))
+	return err
+}
+
+func writeFilePart(writer *multipart.Writer, name string, filePath string, fileName string, contentType string) error {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", `form-data; name="`+escapeQuotes(name)+`"; filename="`+escapeQuotes(fileName)+`"`)
+	header.Set("Content-Type", contentType)
+	partWriter, err := writer.CreatePart(header)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(partWriter, file)
+	return err
+}
+
+func escapeQuotes(s string) string {
+	return quoteEscaper.Replace(s)
+}
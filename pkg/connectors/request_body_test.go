@@ -0,0 +1,118 @@
+package connectors_test
+
+import (
+	"github.com/PxyUp/fitter/pkg/builder"
+	"github.com/PxyUp/fitter/pkg/config"
+	"github.com/PxyUp/fitter/pkg/connectors"
+	"github.com/PxyUp/fitter/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestServer_RequestBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var result string
+		mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		switch mediaType {
+		case "application/x-www-form-urlencoded":
+			_ = r.ParseForm()
+			result = r.PostForm.Get("q") + "|" + r.PostForm.Get("page")
+		case "multipart/form-data":
+			_ = r.ParseMultipartForm(1024)
+			file, header, _ := r.FormFile("upload")
+			content, _ := io.ReadAll(file)
+			result = r.FormValue("title") + "|" + header.Filename + "|" + string(content)
+		case "application/json":
+			content, _ := io.ReadAll(r.Body)
+			result = string(content)
+		}
+		_, _ = w.Write([]byte(result))
+	}))
+	defer server.Close()
+
+	uploadContent := strings.Repeat("file content ", 100000)
+	uploadPath := path.Join(t.TempDir(), "upload.txt")
+	require.NoError(t, os.WriteFile(uploadPath, []byte(uploadContent), 0600))
+
+	index := uint32(3)
+	objectBuilder := func(cfg *config.ObjectConfig, parsedValue builder.Interfacable, index *uint32, input builder.Interfacable, logger logger.Logger) builder.Interfacable {
+		require.NotNil(t, index)
+		return builder.Object(map[string]builder.Interfacable{
+			"query": builder.String(parsedValue.ToInterface().(string)),
+			"index": builder.Number(float64(*index)),
+		})
+	}
+
+	for expected, serverConfig := range map[string]*config.ServerConnectorConfig{
+		`a&b=c|3`: {
+			Form: map[string]string{
+				"q":    "{PL}",
+				"page": "{INDEX}",
+			},
+		},
+		`report "a&b=c"|data.txt|` + uploadContent: {
+			Multipart: []*config.MultipartPart{
+				{Name: "title", Value: `report "{PL}"`},
+				{Name: "upload", File: uploadPath, FileName: "data.txt"},
+			},
+		},
+		`{"query":"a&b=c","index":3}`: {
+			JSON: &config.ObjectConfig{},
+		},
+	} {
+		serverConfig.Method = http.MethodPost
+		body, err := connectors.NewAPI(server.URL, serverConfig, nil).WithObjectBuilder(objectBuilder).Get(builder.PureString("a&b=c"), &index, nil)
+		require.NoError(t, err)
+		if serverConfig.JSON != nil {
+			assert.JSONEq(t, expected, string(body))
+			continue
+		}
+		assert.Equal(t, expected, string(body))
+	}
+}
+
+func TestServer_RequestBodyErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	_, err := connectors.NewAPI(server.URL, &config.ServerConnectorConfig{
+		Method: http.MethodPost,
+		JSON:   &config.ObjectConfig{},
+	}, nil).Get(nil, nil, nil)
+	assert.Error(t, err)
+
+	_, err = connectors.NewAPI(server.URL, &config.ServerConnectorConfig{
+		Method: http.MethodPost,
+		Multipart: []*config.MultipartPart{
+			{Name: "upload", File: path.Join(t.TempDir(), "missing.txt")},
+		},
+	}, nil).Get(nil, nil, nil)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	uploadPath := path.Join(t.TempDir(), "upload.txt")
+	require.NoError(t, os.WriteFile(uploadPath, []byte(strings.Repeat("file content ", 100000)), 0600))
+	goroutines := runtime.NumGoroutine()
+	_, err = connectors.NewAPI(server.URL, &config.ServerConnectorConfig{
+		Method:    http.MethodPost,
+		ProxyPool: "missing",
+		Multipart: []*config.MultipartPart{
+			{Name: "upload", File: uploadPath},
+		},
+	}, nil).Get(nil, nil, nil)
+	assert.Error(t, err)
+	assert.Eventually(t, func() bool {
+		return runtime.NumGoroutine() <= goroutines
+	}, time.Second, 10*time.Millisecond)
+}
@@ -1,13 +1,15 @@
 package connectors
 
 import (
-	"bytes"
 	"context"
+	"github.com/PxyUp/fitter/pkg/auth"
 	"github.com/PxyUp/fitter/pkg/builder"
 	"github.com/PxyUp/fitter/pkg/config"
 	"github.com/PxyUp/fitter/pkg/http_client"
 	"github.com/PxyUp/fitter/pkg/limitter"
 	"github.com/PxyUp/fitter/pkg/logger"
+	"github.com/PxyUp/fitter/pkg/proxy"
+	"github.com/PxyUp/fitter/pkg/session"
 	"github.com/PxyUp/fitter/pkg/utils"
 	"golang.org/x/sync/semaphore"
 	"io"
@@ -15,6 +17,7 @@ import (
 	"net/url"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -28,6 +31,8 @@ type apiConnector struct {
 	logger logger.Logger
 	client *http.Client
 	cfg    *config.ServerConnectorConfig
+
+	objectBuilder ObjectBuilder
 }
 
 var (
@@ -62,64 +67,138 @@ func (api *apiConnector) WithLogger(logger logger.Logger) *apiConnector {
 }
 
 func (api *apiConnector) GetWithHeaders(parsedValue builder.Interfacable, index *uint32, input builder.Interfacable) (http.Header, []byte, error) {
-	return api.get(parsedValue, index, input)
+	resp, body, err := api.get(parsedValue, index, input)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp.Header, body, nil
+}
+
+func (api *apiConnector) GetWithURL(parsedValue builder.Interfacable, index *uint32, input builder.Interfacable) (string, []byte, error) {
+	resp, body, err := api.get(parsedValue, index, input)
+	if err != nil {
+		return "", nil, err
+	}
+	return resp.Request.URL.String(), body, nil
+}
+
+// Stream send request and pass decoded body of the response to handler without buffering
+func (api *apiConnector) Stream(parsedValue builder.Interfacable, index *uint32, input builder.Interfacable, handler func(resp *http.Response, body io.Reader) error) (*http.Response, error) {
+	requestBody, err := api.requestBody(parsedValue, index, input)
+	if err != nil {
+		api.logger.Errorw("unable to create http request body", "error", err.Error())
+		return nil, err
+	}
+
+	return api.send(requestBody, parsedValue, index, input, handler)
 }
 
-func (api *apiConnector) get(parsedValue builder.Interfacable, index *uint32, input builder.Interfacable) (http.Header, []byte, error) {
-	formattedBody := utils.Format(api.cfg.Body, parsedValue, index, input)
+func (api *apiConnector) send(requestBody *payload, parsedValue builder.Interfacable, index *uint32, input builder.Interfacable, handler func(resp *http.Response, body io.Reader) error) (*http.Response, error) {
 	formattedURL := utils.Format(api.url, parsedValue, index, input)
 
 	if formattedURL == "" {
-		return nil, nil, errEmpty
+		return nil, errEmpty
 	}
 
 	err := sem.Acquire(ctx, 1)
 	if err != nil {
 		api.logger.Errorw("unable to acquire semaphore", "method", api.cfg.Method, "url", formattedURL, "error", err.Error())
-		return nil, nil, err
+		return nil, err
 	}
 
 	defer sem.Release(1)
 
-	req, err := http.NewRequest(api.cfg.Method, formattedURL, bytes.NewBufferString(formattedBody))
+	req, err := http.NewRequest(api.cfg.Method, formattedURL, nil)
 
 	if err != nil {
 		api.logger.Errorw("unable to create http request", "error", err.Error())
-		return nil, nil, err
+		return nil, err
 	}
 
 	for k, v := range api.cfg.Headers {
 		req.Header.Add(k, utils.Format(v, parsedValue, index, input))
 	}
 
+	// boundary of multipart body is generated, so content type from headers can not be used
+	contentType := requestBody.contentType
+	if contentType != "" && (strings.HasPrefix(contentType, "multipart/") || req.Header.Get("Content-Type") == "") {
+		req.Header.Set("Content-Type", contentType)
+	}
+
 	client := http_client.GetDefaultClient()
 	if api.client != nil {
 		client = api.client
 	}
 
-	if api.cfg.Proxy != nil {
-		proxyUrl, errProxy := url.Parse(utils.Format(api.cfg.Proxy.Server, parsedValue, index, input))
+	if api.cfg.Session != "" {
+		if jar := session.Jar(api.cfg.Session); jar != nil {
+			sessionClient := *client
+			sessionClient.Jar = jar
+			client = &sessionClient
+		} else {
+			api.logger.Errorw("session not found, request will be sent without cookies", "session", api.cfg.Session)
+		}
+	}
+
+	proxyCfg := api.cfg.Proxy
+	var pool *proxy.Pool
+	var pooledProxy *proxy.Proxy
+	if proxyCfg == nil && api.cfg.ProxyPool != "" {
+		pool = proxy.Get(api.cfg.ProxyPool)
+		if pool == nil {
+			api.logger.Errorw("unable to find proxy pool", "proxy_pool", api.cfg.ProxyPool)
+			return nil, errProxyPoolNotFound
+		}
+
+		pooledProxy, err = pool.Pick(req.URL.Hostname())
+		if err != nil {
+			api.logger.Errorw("unable to pick proxy from pool", "proxy_pool", api.cfg.ProxyPool, "error", err.Error())
+			return nil, err
+		}
+		proxyCfg = pooledProxy.ProxyConfig
+	}
+
+	var proxyUrl *url.URL
+	if proxyCfg != nil {
+		var errProxy error
+		proxyUrl, errProxy = url.Parse(utils.Format(proxyCfg.Server, parsedValue, index, input))
 		if errProxy != nil {
 			api.logger.Errorw("unable to create proxy", "error", errProxy.Error())
-			return nil, nil, err
+			return nil, errProxy
 		}
 
-		if api.cfg.Proxy.Username != "" {
-			if api.cfg.Proxy.Password != "" {
-				proxyUrl.User = url.UserPassword(utils.Format(api.cfg.Proxy.Username, parsedValue, index, input), utils.Format(api.cfg.Proxy.Password, parsedValue, index, input))
+		if proxyCfg.Username != "" {
+			if proxyCfg.Password != "" {
+				proxyUrl.User = url.UserPassword(utils.Format(proxyCfg.Username, parsedValue, index, input), utils.Format(proxyCfg.Password, parsedValue, index, input))
 			} else {
-				proxyUrl.User = url.User(utils.Format(api.cfg.Proxy.Username, parsedValue, index, input))
+				proxyUrl.User = url.User(utils.Format(proxyCfg.Username, parsedValue, index, input))
 			}
 		}
-		api.logger.Debugw("set proxy", "server", api.cfg.Proxy.Server, "username", api.cfg.Proxy.Username, "password", api.cfg.Proxy.Password)
-		client.Transport = &http.Transport{Proxy: http.ProxyURL(proxyUrl)}
+		api.logger.Debugw("set proxy", "server", proxyCfg.Server, "username", proxyCfg.Username, "password", proxyCfg.Password)
+	}
+
+	if proxyUrl != nil || api.cfg.TLS != nil || api.cfg.Transport != nil {
+		transport, errTransport := http_client.GetTransport(proxyUrl, api.cfg.TLS, api.cfg.Transport)
+		if errTransport != nil {
+			api.logger.Errorw("unable to create http transport", "error", errTransport.Error())
+			return nil, errTransport
+		}
+		transportClient := *client
+		transportClient.Transport = transport
+		client = &transportClient
+	}
+
+	if api.cfg.Auth != nil {
+		authClient := *client
+		authClient.Transport = auth.Transport(api.cfg.Auth, req.URL.Host, client.Transport, api.logger.With("component", "auth"))
+		client = &authClient
 	}
 
 	if hostLimit := limitter.HostLimiter(req.Host); hostLimit != nil {
 		errHostLimit := hostLimit.Acquire(ctx, 1)
 		if errHostLimit != nil {
 			api.logger.Errorw("unable to acquire host limit semaphore", "method", api.cfg.Method, "url", formattedURL, "error", errHostLimit.Error(), "host", req.Host)
-			return nil, nil, errHostLimit
+			return nil, errHostLimit
 		}
 		defer hostLimit.Release(1)
 	}
@@ -130,26 +209,78 @@ func (api *apiConnector) get(parsedValue builder.Interfacable, index *uint32, in
 	}
 	reqCtx, cancel := context.WithTimeout(ctx, tt)
 	defer cancel()
+	if proxyUrl != nil && proxyUrl.User != nil {
+		reqCtx = http_client.WithProxyUser(reqCtx, proxyUrl.User)
+	}
 
-	api.logger.Infow("sending request to url", "url", formattedURL, "body", formattedBody)
+	requestBody.attach(req)
+	api.logger.Infow("sending request to url", "url", formattedURL, "body", requestBody.String())
 	resp, err := client.Do(req.WithContext(reqCtx))
+	if pool != nil {
+		statusCode := 0
+		if resp != nil {
+			statusCode = resp.StatusCode
+		}
+		pool.Report(pooledProxy, statusCode, err)
+	}
 	if err != nil {
 		api.logger.Errorw("unable to send http request", "method", api.cfg.Method, "url", formattedURL, "error", err.Error())
-		return nil, nil, err
+		return nil, err
 	}
 
 	if resp != nil && resp.Body != nil {
 		defer resp.Body.Close()
 	}
 
-	bytes, err := io.ReadAll(resp.Body)
+	body, err := decodeContentEncoding(resp.Body, resp.Header.Get("Content-Encoding"))
+	if err != nil {
+		api.logger.Errorw("unable to decode http response", "content_encoding", resp.Header.Get("Content-Encoding"), "error", err.Error())
+		return nil, err
+	}
+	defer body.Close()
+
+	if err = handler(resp, body); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (api *apiConnector) get(parsedValue builder.Interfacable, index *uint32, input builder.Interfacable) (*http.Response, []byte, error) {
+	requestBody, err := api.requestBody(parsedValue, index, input)
+	if err != nil {
+		api.logger.Errorw("unable to create http request body", "error", err.Error())
+		return nil, nil, err
+	}
+
+	return api.getWithBody(requestBody, parsedValue, index, input)
+}
+
+func (api *apiConnector) getWithBody(requestBody *payload, parsedValue builder.Interfacable, index *uint32, input builder.Interfacable) (*http.Response, []byte, error) {
+	var bytes []byte
+	resp, err := api.send(requestBody, parsedValue, index, input, func(resp *http.Response, body io.Reader) error {
+		content, errRead := utils.ReadAll(body, utils.BodyLimit(api.cfg.MaxBodySize))
+		if errRead != nil {
+			api.logger.Errorw("unable to read http response", "error", errRead.Error())
+			return errRead
+		}
+
+		utf8Content, errUTF8 := toUTF8(content, resp.Header.Get("Content-Type"), api.cfg.Charset)
+		if errUTF8 != nil {
+			api.logger.Errorw("unable to convert response to utf-8, raw response will be used", "content_type", resp.Header.Get("Content-Type"), "charset", api.cfg.Charset, "error", errUTF8.Error())
+		} else {
+			content = utf8Content
+		}
+
+		api.logger.Debugw("returned response", "status_code", resp.Status, "body", string(content))
+		bytes = content
+		return nil
+	})
 	if err != nil {
-		api.logger.Errorw("unable to read http response", "error", err.Error())
 		return nil, nil, err
 	}
 
-	api.logger.Debugw("returned response", "status_code", resp.Status, "body", string(bytes))
-	return resp.Header, bytes, nil
+	return resp, bytes, nil
 }
 
 func (api *apiConnector) Get(parsedValue builder.Interfacable, index *uint32, input builder.Interfacable) ([]byte, error) {
@@ -0,0 +1,113 @@
+package connectors_test
+
+import (
+	"compress/gzip"
+	"github.com/PxyUp/fitter/pkg/config"
+	"github.com/PxyUp/fitter/pkg/connectors"
+	"github.com/PxyUp/fitter/pkg/utils"
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestServer_ResponseDecoding(t *testing.T) {
+	asciiPrefix := strings.Repeat("a", 1100)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/cp1251", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write(append([]byte(`<html><head><meta charset="windows-1251"></head><body><h1>`), append([]byte{0xcf, 0xf0, 0xe8, 0xe2, 0xe5, 0xf2}, []byte(`</h1></body></html>`)...)...))
+	})
+	mux.HandleFunc("/override", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte{'<', 'h', '1', '>', 0xe9, 't', 0xe9, '<', '/', 'h', '1', '>'})
+	})
+	mux.HandleFunc("/br", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("Content-Encoding", "br")
+		writer := brotli.NewWriter(w)
+		_, _ = writer.Write([]byte(`<h1>brotli</h1>`))
+		_ = writer.Close()
+	})
+	mux.HandleFunc("/zstd", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("Content-Encoding", "zstd")
+		writer, _ := zstd.NewWriter(w)
+		_, _ = writer.Write([]byte(`<h1>zstd</h1>`))
+		_ = writer.Close()
+	})
+	mux.HandleFunc("/gzip", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("Content-Encoding", "gzip")
+		writer := gzip.NewWriter(w)
+		_, _ = writer.Write([]byte(`<h1>gzip</h1>`))
+		_ = writer.Close()
+	})
+	mux.HandleFunc("/json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"padding": "` + asciiPrefix + `", "text": "Привет"}`))
+	})
+	mux.HandleFunc("/html", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(`<p>` + asciiPrefix + `</p><h1>Привет</h1>`))
+	})
+	mux.HandleFunc("/empty", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		w.WriteHeader(http.StatusNoContent)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	for path, expected := range map[string]string{
+		"/cp1251":   "Привет",
+		"/override": "été",
+		"/br":       "brotli",
+		"/zstd":     "zstd",
+		"/gzip":     "gzip",
+		"/json":     `"text": "Привет"`,
+		"/html":     "<h1>Привет</h1>",
+	} {
+		serverConfig := &config.ServerConnectorConfig{
+			Method: http.MethodGet,
+		}
+		if path == "/override" {
+			serverConfig.Charset = "ISO-8859-1"
+		}
+
+		body, err := connectors.NewAPI(server.URL+path, serverConfig, nil).Get(nil, nil, nil)
+		require.NoError(t, err, path)
+		assert.Contains(t, string(body), expected, path)
+	}
+
+	for _, method := range []string{http.MethodGet, http.MethodHead} {
+		body, err := connectors.NewAPI(server.URL+"/empty", &config.ServerConnectorConfig{
+			Method: method,
+		}, nil).Get(nil, nil, nil)
+		require.NoError(t, err, method)
+		assert.Empty(t, body, method)
+	}
+}
+
+func TestServer_MaxBodySize(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`<html><body><h1>too big page</h1></body></html>`))
+	}))
+	defer server.Close()
+
+	_, err := connectors.NewAPI(server.URL, &config.ServerConnectorConfig{
+		Method:      http.MethodGet,
+		MaxBodySize: 10,
+	}, nil).Get(nil, nil, nil)
+	assert.ErrorIs(t, err, utils.ErrBodyTooLarge)
+
+	body, err := connectors.NewAPI(server.URL, &config.ServerConnectorConfig{
+		Method:      http.MethodGet,
+		MaxBodySize: 1024,
+	}, nil).Get(nil, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, `<html><body><h1>too big page</h1></body></html>`, string(body))
+}
@@ -0,0 +1,67 @@
+package connectors
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/PxyUp/fitter/pkg/builder"
+	"github.com/PxyUp/fitter/pkg/config"
+	"github.com/PxyUp/fitter/pkg/database"
+	"github.com/PxyUp/fitter/pkg/logger"
+	"github.com/PxyUp/fitter/pkg/utils"
+	"time"
+)
+
+type sqlConnector struct {
+	cfg    *config.SQLConnectorConfig
+	logger logger.Logger
+}
+
+func NewSQL(cfg *config.SQLConnectorConfig) *sqlConnector {
+	return &sqlConnector{
+		cfg:    cfg,
+		logger: logger.Null,
+	}
+}
+
+func (s *sqlConnector) WithLogger(logger logger.Logger) *sqlConnector {
+	s.logger = logger
+	return s
+}
+
+func (s *sqlConnector) Get(parsedValue builder.Interfacable, index *uint32, input builder.Interfacable) ([]byte, error) {
+	db, release, err := database.Get(s.cfg.Driver, utils.Format(s.cfg.DSN, parsedValue, index, input))
+	if err != nil {
+		s.logger.Errorw("unable to open database", "driver", string(s.cfg.Driver), "error", err.Error())
+		return nil, err
+	}
+	defer release()
+
+	params := make([]string, len(s.cfg.Params))
+	for i, param := range s.cfg.Params {
+		params[i] = utils.Format(param, parsedValue, index, input)
+	}
+
+	tt := timeout
+	if s.cfg.Timeout > 0 {
+		tt = time.Duration(s.cfg.Timeout) * time.Second
+	}
+	queryCtx, cancel := context.WithTimeout(ctx, tt)
+	defer cancel()
+
+	s.logger.Infow("sending sql query", "query", s.cfg.Query, "params", fmt.Sprintf("%q", params))
+	rows, err := db.QueryContext(queryCtx, s.cfg.Query, database.Args(params)...)
+	if err != nil {
+		s.logger.Errorw("unable to execute sql query", "query", s.cfg.Query, "error", err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	result, err := database.Rows(rows)
+	if err != nil {
+		s.logger.Errorw("unable to read sql rows", "query", s.cfg.Query, "error", err.Error())
+		return nil, err
+	}
+
+	return json.Marshal(result)
+}
@@ -0,0 +1,69 @@
+package connectors_test
+
+import (
+	"fmt"
+	"github.com/PxyUp/fitter/pkg/builder"
+	"github.com/PxyUp/fitter/pkg/config"
+	"github.com/PxyUp/fitter/pkg/connectors"
+	"github.com/PxyUp/fitter/pkg/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"path"
+	"testing"
+)
+
+func TestSQL(t *testing.T) {
+	dsn := path.Join(t.TempDir(), "catalog.db")
+	db, release, err := database.Get(config.SQLite, dsn)
+	require.NoError(t, err)
+	defer release()
+	_, err = db.Exec(`CREATE TABLE catalog (sku TEXT PRIMARY KEY, price REAL, stock INTEGER)`)
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO catalog VALUES ('A-1', 10.5, 3), ('B-2', 20, 0)`)
+	require.NoError(t, err)
+
+	connector := connectors.NewSQL(&config.SQLConnectorConfig{
+		Driver: config.SQLite,
+		DSN:    dsn,
+		Query:  `SELECT price, stock FROM catalog WHERE sku = ?`,
+		Params: []string{"{PL}"},
+	})
+
+	for sku, expected := range map[string]string{
+		"A-1":           `[{"price": 10.5, "stock": 3}]`,
+		"C-3":           `[]`,
+		"x' OR '1'='1":  `[]`,
+		`x" OR "1"="1"`: `[]`,
+	} {
+		body, errGet := connector.Get(builder.PureString(sku), nil, nil)
+		require.NoError(t, errGet)
+		assert.JSONEq(t, expected, string(body), sku)
+	}
+
+	_, err = connectors.NewSQL(&config.SQLConnectorConfig{
+		Driver: "unknown",
+		DSN:    dsn,
+	}).Get(nil, nil, nil)
+	assert.Error(t, err)
+}
+
+func TestSQL_PoolEviction(t *testing.T) {
+	dir := t.TempDir()
+	used, release, err := database.Get(config.SQLite, path.Join(dir, "used.db"))
+	require.NoError(t, err)
+	idle, releaseIdle, err := database.Get(config.SQLite, path.Join(dir, "idle.db"))
+	require.NoError(t, err)
+	releaseIdle()
+
+	for i := 0; i < 20; i++ {
+		_, releaseOther, errGet := database.Get(config.SQLite, path.Join(dir, fmt.Sprintf("%d.db", i)))
+		require.NoError(t, errGet)
+		releaseOther()
+	}
+
+	// evicted pool is closed only after it is released
+	assert.Error(t, idle.Ping())
+	assert.NoError(t, used.Ping())
+	release()
+	assert.Error(t, used.Ping())
+}
@@ -0,0 +1,124 @@
+package connectors
+
+import (
+	"context"
+	"fmt"
+	"github.com/PxyUp/fitter/pkg/builder"
+	"github.com/PxyUp/fitter/pkg/config"
+	"github.com/PxyUp/fitter/pkg/logger"
+	"github.com/PxyUp/fitter/pkg/stream"
+	"github.com/PxyUp/fitter/pkg/utils"
+	"time"
+)
+
+const (
+	defaultStreamWindow = 60 * time.Second
+)
+
+type webSocketConnector struct {
+	url    string
+	cfg    *config.WebSocketConnectorConfig
+	logger logger.Logger
+}
+
+type sseConnector struct {
+	url    string
+	cfg    *config.SSEConnectorConfig
+	logger logger.Logger
+}
+
+func NewWebSocket(url string, cfg *config.WebSocketConnectorConfig) *webSocketConnector {
+	return &webSocketConnector{
+		url:    url,
+		cfg:    cfg,
+		logger: logger.Null,
+	}
+}
+
+func (w *webSocketConnector) WithLogger(logger logger.Logger) *webSocketConnector {
+	w.logger = logger
+	return w
+}
+
+func (w *webSocketConnector) Get(parsedValue builder.Interfacable, index *uint32, input builder.Interfacable) ([]byte, error) {
+	formattedURL := utils.Format(w.url, parsedValue, index, input)
+	if formattedURL == "" {
+		return nil, errEmpty
+	}
+
+	window := streamWindow(w.cfg.Window)
+	dialCtx, cancel := context.WithTimeout(ctx, window)
+	defer cancel()
+
+	w.logger.Infow("connecting to websocket", "url", formattedURL)
+	s, err := stream.DialWebSocket(dialCtx, formattedURL, formatHeaders(w.cfg.Headers, parsedValue, index, input), utils.Format(w.cfg.Subscribe, parsedValue, index, input))
+	if err != nil {
+		w.logger.Errorw("unable to connect to websocket", "url", formattedURL, "error", err.Error())
+		return nil, err
+	}
+
+	return collectStream(s, w.cfg.MaxMessages, w.cfg.Window, w.logger)
+}
+
+func NewSSE(url string, cfg *config.SSEConnectorConfig) *sseConnector {
+	return &sseConnector{
+		url:    url,
+		cfg:    cfg,
+		logger: logger.Null,
+	}
+}
+
+func (s *sseConnector) WithLogger(logger logger.Logger) *sseConnector {
+	s.logger = logger
+	return s
+}
+
+func (s *sseConnector) Get(parsedValue builder.Interfacable, index *uint32, input builder.Interfacable) ([]byte, error) {
+	formattedURL := utils.Format(s.url, parsedValue, index, input)
+	if formattedURL == "" {
+		return nil, errEmpty
+	}
+
+	streamCtx, cancel := context.WithTimeout(ctx, streamWindow(s.cfg.Window))
+	defer cancel()
+
+	s.logger.Infow("connecting to event stream", "url", formattedURL)
+	sseStream, err := stream.DialSSE(streamCtx, formattedURL, formatHeaders(s.cfg.Headers, parsedValue, index, input), s.cfg.Event)
+	if err != nil {
+		s.logger.Errorw("unable to connect to event stream", "url", formattedURL, "error", err.Error())
+		return nil, err
+	}
+
+	return collectStream(sseStream, s.cfg.MaxMessages, s.cfg.Window, s.logger)
+}
+
+func streamWindow(window uint32) time.Duration {
+	if window > 0 {
+		return time.Duration(window) * time.Second
+	}
+	return defaultStreamWindow
+}
+
+// collectStream return json array of the messages, only first message is collected if limits are empty
+func collectStream(s stream.Stream, maxMessages uint32, window uint32, logger logger.Logger) ([]byte, error) {
+	if maxMessages == 0 && window == 0 {
+		maxMessages = 1
+	}
+
+	messages, err := stream.Collect(s, maxMessages, streamWindow(window))
+	if err != nil {
+		logger.Errorw("unable to read messages from stream", "error", err.Error())
+		return nil, err
+	}
+
+	logger.Debugw("collected messages from stream", "count", fmt.Sprintf("%d", len(messages)))
+	return []byte(builder.Array(messages).ToJson()), nil
+}
+
+func formatHeaders(headers map[string]string, parsedValue builder.Interfacable, index *uint32, input builder.Interfacable) map[string]string {
+	formatted := make(map[string]string, len(headers))
+	for k, v := range headers {
+		formatted[k] = utils.Format(v, parsedValue, index, input)
+	}
+	return formatted
+}
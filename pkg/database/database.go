@@ -0,0 +1,157 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/PxyUp/fitter/pkg/config"
+	_ "github.com/lib/pq"
+	_ "modernc.org/sqlite"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var (
+	errUnknownDriver = errors.New("unknown sql driver")
+
+	drivers = map[config.SQLDriver]string{
+		config.SQLite:   "sqlite",
+		config.Postgres: "postgres",
+	}
+
+	pools = make(map[string]*pool)
+	// used keys of the pools from least to most recently used
+	used  []string
+	mutex sync.Mutex
+)
+
+const (
+	// maxPools is amount of kept pools, DSN can be templated, so pool of each value is not kept forever
+	maxPools = 16
+)
+
+type pool struct {
+	db      *sql.DB
+	refs    int
+	evicted bool
+}
+
+// Get return shared connection pool for driver and dsn, release must be called after pool is not used.
+// Least recently used pool is closed when there are more than maxPools pools and nobody use it
+func Get(driver config.SQLDriver, dsn string) (*sql.DB, func(), error) {
+	driverName, ok := drivers[driver]
+	if !ok {
+		return nil, nil, errUnknownDriver
+	}
+
+	key := driverName + "|" + dsn
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	p, ok := pools[key]
+	if !ok {
+		db, err := sql.Open(driverName, dsn)
+		if err != nil {
+			return nil, nil, err
+		}
+		p = &pool{
+			db: db,
+		}
+		pools[key] = p
+	}
+
+	p.refs++
+	markUsed(key)
+	evict()
+
+	return p.db, func() {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		p.refs--
+		if p.evicted && p.refs == 0 {
+			_ = p.db.Close()
+		}
+	}, nil
+}
+
+func markUsed(key string) {
+	for i, usedKey := range used {
+		if usedKey == key {
+			used = append(used[:i], used[i+1:]...)
+			break
+		}
+	}
+	used = append(used, key)
+}
+
+// evict remove least recently used pools over maxPools, pool is closed after last release
+func evict() {
+	for len(used) > maxPools {
+		key := used[0]
+		used = used[1:]
+
+		p := pools[key]
+		delete(pools, key)
+		p.evicted = true
+		if p.refs == 0 {
+			_ = p.db.Close()
+		}
+	}
+}
+
+// Args convert params into query arguments
+func Args(params []string) []interface{} {
+	args := make([]interface{}, len(params))
+	for i, param := range params {
+		args[i] = param
+	}
+	return args
+}
+
+// Rows read all rows as objects, bytes are converted to strings
+func Rows(rows *sql.Rows) ([]map[string]interface{}, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]map[string]interface{}, 0)
+	for rows.Next() {
+		values := make([]interface{}, len(columns))
+		pointers := make([]interface{}, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+
+		if err = rows.Scan(pointers...); err != nil {
+			return nil, err
+		}
+
+		row := make(map[string]interface{}, len(columns))
+		for i, column := range columns {
+			if bytes, ok := values[i].([]byte); ok {
+				row[column] = string(bytes)
+				continue
+			}
+			row[column] = values[i]
+		}
+		result = append(result, row)
+	}
+
+	return result, rows.Err()
+}
+
+// Placeholder return placeholder of the query argument with index from 1
+func Placeholder(driver config.SQLDriver, index int) string {
+	if driver == config.Postgres {
+		return "$" + strconv.Itoa(index)
+	}
+	return "?"
+}
+
+// QuoteIdentifier quote name of the table or column
+func QuoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
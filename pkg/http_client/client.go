@@ -5,8 +5,12 @@ import (
 	"time"
 )
 
+// GetDefaultClient return new client which share pooled default transport
 func GetDefaultClient() *http.Client {
+	transport, _ := GetTransport(nil, nil, nil)
+
 	return &http.Client{
-		Timeout: time.Minute * 2,
+		Timeout:   time.Minute * 2,
+		Transport: transport,
 	}
 }
@@ -0,0 +1,173 @@
+package http_client
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/PxyUp/fitter/pkg/config"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	defaultMaxIdleConnsPerHost = 100
+)
+
+var (
+	errInvalidCA         = errors.New("unable to parse CA certificates")
+	errInvalidTLSVersion = errors.New("unknown TLS version")
+
+	transports = make(map[string]*http.Transport)
+	mutex      sync.Mutex
+
+	tlsVersions = map[string]uint16{
+		"1.0": tls.VersionTLS10,
+		"1.1": tls.VersionTLS11,
+		"1.2": tls.VersionTLS12,
+		"1.3": tls.VersionTLS13,
+	}
+)
+
+type proxyUserKey struct{}
+
+// WithProxyUser put credentials of the proxy into context of the request, shared transport send them to the proxy.
+// Credentials are not part of the transport, so rotating credentials do not create new transport for each value
+func WithProxyUser(ctx context.Context, user *url.Userinfo) context.Context {
+	return context.WithValue(ctx, proxyUserKey{}, user)
+}
+
+// withoutUser return copy of the proxy url without credentials
+func withoutUser(proxy *url.URL) *url.URL {
+	withoutUser := *proxy
+	withoutUser.User = nil
+	return &withoutUser
+}
+
+func transportKey(proxy *url.URL, tlsCfg *config.TLSConfig, transportCfg *config.TransportConfig) string {
+	proxyKey := ""
+	if proxy != nil {
+		proxyKey = withoutUser(proxy).String()
+	}
+	tlsKey, _ := json.Marshal(tlsCfg)
+	transportKey, _ := json.Marshal(transportCfg)
+
+	return fmt.Sprintf("%s|%s|%s", proxyKey, tlsKey, transportKey)
+}
+
+// GetTransport return shared transport for proxy, TLS and transport settings, so connections are reused between requests.
+// Credentials of the proxy url are ignored, they are taken from request context(see WithProxyUser)
+func GetTransport(proxy *url.URL, tlsCfg *config.TLSConfig, transportCfg *config.TransportConfig) (*http.Transport, error) {
+	key := transportKey(proxy, tlsCfg, transportCfg)
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	if transport, ok := transports[key]; ok {
+		return transport, nil
+	}
+
+	transport, err := newTransport(proxy, tlsCfg, transportCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	transports[key] = transport
+	return transport, nil
+}
+
+func newTransport(proxy *url.URL, tlsCfg *config.TLSConfig, transportCfg *config.TransportConfig) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConnsPerHost = defaultMaxIdleConnsPerHost
+
+	if proxy != nil {
+		proxyURL := withoutUser(proxy)
+		transport.Proxy = func(req *http.Request) (*url.URL, error) {
+			user, ok := req.Context().Value(proxyUserKey{}).(*url.Userinfo)
+			if !ok || user == nil {
+				return proxyURL, nil
+			}
+			// connections with different credentials are not shared, transport use credentials in key of the connection
+			withUser := *proxyURL
+			withUser.User = user
+			return &withUser, nil
+		}
+	}
+
+	if tlsCfg != nil {
+		tlsClientConfig, err := NewTLSConfig(tlsCfg)
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig = tlsClientConfig
+	}
+
+	if transportCfg != nil {
+		if transportCfg.DisableHTTP2 {
+			transport.ForceAttemptHTTP2 = false
+			transport.TLSNextProto = make(map[string]func(string, *tls.Conn) http.RoundTripper)
+		}
+		transport.DisableKeepAlives = transportCfg.DisableKeepAlives
+		if transportCfg.MaxIdleConns > 0 {
+			transport.MaxIdleConns = transportCfg.MaxIdleConns
+		}
+		if transportCfg.MaxIdleConnsPerHost > 0 {
+			transport.MaxIdleConnsPerHost = transportCfg.MaxIdleConnsPerHost
+		}
+		if transportCfg.MaxConnsPerHost > 0 {
+			transport.MaxConnsPerHost = transportCfg.MaxConnsPerHost
+		}
+		if transportCfg.IdleConnTimeout > 0 {
+			transport.IdleConnTimeout = time.Duration(transportCfg.IdleConnTimeout) * time.Second
+		}
+	}
+
+	return transport, nil
+}
+
+// NewTLSConfig create tls.Config with custom CA, client certificate and min version
+func NewTLSConfig(cfg *config.TLSConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+		ServerName:         cfg.ServerName,
+	}
+
+	if cfg.MinVersion != "" {
+		version, ok := tlsVersions[cfg.MinVersion]
+		if !ok {
+			return nil, errInvalidTLSVersion
+		}
+		tlsConfig.MinVersion = version
+	}
+
+	if cfg.CAFile != "" {
+		pem, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, err
+		}
+
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, errInvalidCA
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if cfg.CertFile != "" || cfg.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
@@ -0,0 +1,80 @@
+package http_client_test
+
+import (
+	"context"
+	"encoding/pem"
+	"github.com/PxyUp/fitter/pkg/config"
+	"github.com/PxyUp/fitter/pkg/http_client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path"
+	"testing"
+)
+
+func TestGetTransport(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Proto))
+	}))
+	defer server.Close()
+
+	caFile := path.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0600))
+
+	first, err := http_client.GetTransport(nil, &config.TLSConfig{CAFile: caFile}, &config.TransportConfig{MaxIdleConnsPerHost: 10})
+	require.NoError(t, err)
+	second, err := http_client.GetTransport(nil, &config.TLSConfig{CAFile: caFile}, &config.TransportConfig{MaxIdleConnsPerHost: 10})
+	require.NoError(t, err)
+	assert.Same(t, first, second)
+	assert.Equal(t, 10, first.MaxIdleConnsPerHost)
+
+	defaultTransport, err := http_client.GetTransport(nil, nil, nil)
+	require.NoError(t, err)
+	assert.NotSame(t, first, defaultTransport)
+
+	_, err = (&http.Client{Transport: defaultTransport}).Get(server.URL)
+	assert.Error(t, err)
+
+	resp, err := (&http.Client{Transport: first}).Get(server.URL)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	_, err = http_client.GetTransport(nil, &config.TLSConfig{MinVersion: "2.0"}, nil)
+	assert.Error(t, err)
+}
+
+func TestGetTransport_ProxyUser(t *testing.T) {
+	proxyServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Header.Get("Proxy-Authorization")))
+	}))
+	defer proxyServer.Close()
+
+	proxyURL, err := url.Parse(proxyServer.URL)
+	require.NoError(t, err)
+
+	first, err := http_client.GetTransport(proxyURL, nil, nil)
+	require.NoError(t, err)
+	proxyURL.User = url.UserPassword("session-1", "secret")
+	second, err := http_client.GetTransport(proxyURL, nil, nil)
+	require.NoError(t, err)
+	assert.Same(t, first, second)
+
+	for _, user := range []string{"session-1", "session-2"} {
+		req, errRequest := http.NewRequestWithContext(http_client.WithProxyUser(context.Background(), url.UserPassword(user, "secret")), http.MethodGet, "http://example.com", nil)
+		require.NoError(t, errRequest)
+
+		resp, errResponse := (&http.Client{Transport: first}).Do(req)
+		require.NoError(t, errResponse)
+		body, errRead := io.ReadAll(resp.Body)
+		require.NoError(t, errRead)
+		_ = resp.Body.Close()
+
+		expected := &http.Request{Header: make(http.Header)}
+		expected.SetBasicAuth(user, "secret")
+		assert.Equal(t, expected.Header.Get("Authorization"), string(body))
+	}
+}
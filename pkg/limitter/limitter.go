@@ -2,6 +2,7 @@ package limitter
 
 import (
 	"github.com/PxyUp/fitter/pkg/config"
+	"github.com/PxyUp/fitter/pkg/proxy"
 	"golang.org/x/sync/semaphore"
 	"sync"
 )
@@ -11,6 +12,7 @@ var (
 	chromiumInstance   *semaphore.Weighted
 	dockerContainers   *semaphore.Weighted
 	playwrightInstance *semaphore.Weighted
+	maxBodySize        int64
 
 	once = &sync.Once{}
 )
@@ -39,9 +41,16 @@ func SetLimits(limits *config.Limits) {
 		setSemaphoreLimit(&dockerContainers, limits.DockerContainers)
 		setSemaphoreLimit(&playwrightInstance, limits.PlaywrightInstance)
 		setRequestPerHost(limits.HostRequestLimiter)
+		proxy.SetPools(limits.ProxyPools)
+		maxBodySize = limits.MaxBodySize
 	})
 }
 
+// MaxBodySize return global limit of the response body size in bytes, 0 means without limit
+func MaxBodySize() int64 {
+	return maxBodySize
+}
+
 func HostLimiter(host string) *semaphore.Weighted {
 	if hostLimit, ok := limitPerHost[host]; ok {
 		return hostLimit
@@ -0,0 +1,151 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"github.com/PxyUp/fitter/pkg/builder"
+	"github.com/PxyUp/fitter/pkg/config"
+	"github.com/PxyUp/fitter/pkg/logger"
+	"github.com/PxyUp/fitter/pkg/utils"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"sync"
+)
+
+var (
+	_ Notifier      = &amqpNotifier{}
+	_ batchNotifier = &amqpNotifier{}
+
+	errAMQPNack = errors.New("message is not acknowledged by broker")
+
+	amqpConns     = make(map[string]*amqp.Connection)
+	amqpConnMutex sync.Mutex
+)
+
+type amqpNotifier struct {
+	logger logger.Logger
+	name   string
+	cfg    *config.AMQPNotifierConfig
+}
+
+func NewAMQP(name string, cfg *config.AMQPNotifierConfig) *amqpNotifier {
+	return &amqpNotifier{
+		logger: logger.Null,
+		name:   name,
+		cfg:    cfg,
+	}
+}
+
+func (a *amqpNotifier) WithLogger(logger logger.Logger) *amqpNotifier {
+	a.logger = logger
+	return a
+}
+
+func (a *amqpNotifier) GetLogger() logger.Logger {
+	return a.logger
+}
+
+// getAMQPConn return shared connection, closed connections are replaced with new one
+func getAMQPConn(url string) (*amqp.Connection, error) {
+	amqpConnMutex.Lock()
+	defer amqpConnMutex.Unlock()
+
+	if conn, ok := amqpConns[url]; ok && !conn.IsClosed() {
+		return conn, nil
+	}
+
+	conn, err := amqp.Dial(url)
+	if err != nil {
+		return nil, err
+	}
+	amqpConns[url] = conn
+	return conn, nil
+}
+
+func (a *amqpNotifier) notify(record *singleRecord, input builder.Interfacable) error {
+	return a.notifyBatch([]*singleRecord{record}, input)
+}
+
+func (a *amqpNotifier) notifyBatch(records []*singleRecord, input builder.Interfacable) error {
+	conn, err := getAMQPConn(utils.Format(a.cfg.Url, nil, nil, input))
+	if err != nil {
+		a.logger.Errorw("cant connect to amqp", "error", err.Error())
+		return err
+	}
+
+	channel, err := conn.Channel()
+	if err != nil {
+		a.logger.Errorw("cant open amqp channel", "error", err.Error())
+		return err
+	}
+	defer channel.Close()
+
+	if err = channel.Confirm(false); err != nil {
+		a.logger.Errorw("cant enable publisher confirms", "error", err.Error())
+		return err
+	}
+
+	for _, batch := range splitBatches(records, a.cfg.BatchSize) {
+		messages, errMessages := toQueueMessages(batch, a.cfg.KeyPath)
+		if errMessages != nil {
+			a.logger.Errorw("cant marshal message", "error", errMessages.Error())
+			return errMessages
+		}
+
+		if err = a.publish(channel, messages, input); err != nil {
+			a.logger.Errorw("cant publish messages", "error", err.Error())
+			return err
+		}
+	}
+
+	return nil
+}
+
+// publishing build message with formatted routing key and headers
+func (a *amqpNotifier) publishing(msg *queueMessage, input builder.Interfacable) (string, amqp.Publishing) {
+	headers := amqp.Table{"name": a.name}
+	for k, v := range a.cfg.Headers {
+		headers[k] = formatWithRecord(v, msg.record, input)
+	}
+
+	return formatWithRecord(a.cfg.RoutingKey, msg.record, input), amqp.Publishing{
+		ContentType:  "application/json",
+		DeliveryMode: amqp.Persistent,
+		MessageId:    msg.key,
+		Headers:      headers,
+		Body:         msg.body,
+	}
+}
+
+func (a *amqpNotifier) publish(channel *amqp.Channel, messages []*queueMessage, input builder.Interfacable) error {
+	ctx, cancel := context.WithTimeout(context.Background(), queueTimeout)
+	defer cancel()
+
+	confirms := make([]*amqp.DeferredConfirmation, len(messages))
+	for i, msg := range messages {
+		routingKey, publishing := a.publishing(msg, input)
+		confirm, err := channel.PublishWithDeferredConfirmWithContext(
+			ctx,
+			a.cfg.Exchange,
+			routingKey,
+			false,
+			false,
+			publishing,
+		)
+		if err != nil {
+			return err
+		}
+		confirms[i] = confirm
+	}
+
+	for _, confirm := range confirms {
+		ack, err := confirm.WaitContext(ctx)
+		if err != nil {
+			return err
+		}
+		if !ack {
+			return errAMQPNack
+		}
+	}
+
+	return nil
+}
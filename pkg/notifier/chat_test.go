@@ -0,0 +1,323 @@
+package notifier_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"github.com/PxyUp/fitter/pkg/config"
+	"github.com/PxyUp/fitter/pkg/logger"
+	"github.com/PxyUp/fitter/pkg/notifier"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type chatServer struct {
+	mutex    sync.Mutex
+	requests []*http.Request
+	bodies   []map[string]interface{}
+	sizes    []int
+}
+
+func newChatServer(t *testing.T) (*chatServer, *httptest.Server) {
+	chat := &chatServer{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		var decoded map[string]interface{}
+		require.NoError(t, json.Unmarshal(body, &decoded))
+
+		chat.mutex.Lock()
+		chat.requests = append(chat.requests, r)
+		chat.bodies = append(chat.bodies, decoded)
+		chat.sizes = append(chat.sizes, len(body))
+		chat.mutex.Unlock()
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+	return chat, server
+}
+
+func TestSlackNotifier(t *testing.T) {
+	chat, server := newChatServer(t)
+
+	slackNotifier := notifier.NewSlack("news", &config.SlackNotifierConfig{
+		WebhookUrl: server.URL + "/hook",
+		Channel:    "#news",
+		OnlyMsg:    true,
+	})
+	longText := strings.Repeat("a", 30000) + "\n" + strings.Repeat("b", 30000)
+	require.NoError(t, notifier.Inform(slackNotifier, "news", result(strconv.Quote(longText)), nil, false, logger.Null, nil))
+
+	require.Len(t, chat.bodies, 2)
+	assert.Equal(t, strings.Repeat("a", 30000)+"\n", chat.bodies[0]["text"])
+	assert.Equal(t, strings.Repeat("b", 30000), chat.bodies[1]["text"])
+	assert.Equal(t, "#news", chat.bodies[0]["channel"])
+
+	blocksNotifier := notifier.NewSlack("news", &config.SlackNotifierConfig{
+		WebhookUrl: server.URL + "/hook",
+		Blocks:     json.RawMessage(`[{"type": "section", "text": {"type": "mrkdwn", "text": "*{{{title}}}*"}}]`),
+		OnlyMsg:    true,
+	})
+	require.NoError(t, notifier.Inform(blocksNotifier, "news", result(`{"title": "Go 2"}`), nil, false, logger.Null, nil))
+
+	require.Len(t, chat.bodies, 3)
+	blocks, err := json.Marshal(chat.bodies[2]["blocks"])
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"type": "section", "text": {"type": "mrkdwn", "text": "*Go 2*"}}]`, string(blocks))
+	assert.Equal(t, `{"title": "Go 2"}`, chat.bodies[2]["text"])
+}
+
+func TestDiscordNotifier(t *testing.T) {
+	chat, server := newChatServer(t)
+
+	discordNotifier := notifier.NewDiscord("news", &config.DiscordNotifierConfig{
+		WebhookUrl: server.URL + "/webhook",
+		Username:   "fitter",
+		Embed: &config.DiscordEmbedConfig{
+			Title: "{{{title}}}",
+			Url:   "https://example.com/{{{id}}}",
+			Color: 5814783,
+		},
+		OnlyMsg:  true,
+		Truncate: true,
+	})
+	require.NoError(t, notifier.Inform(discordNotifier, "news", result(`[{"id": 1, "title": "First"}, {"id": 2, "title": "Second"}]`), nil, true, logger.Null, nil))
+
+	require.Len(t, chat.bodies, 2)
+	raw, err := json.Marshal(chat.bodies[1])
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"username": "fitter",
+		"embeds": [{"title": "Second", "description": "{\"id\":2,\"title\":\"Second\"}", "url": "https://example.com/2", "color": 5814783}]
+	}`, string(raw))
+
+	textNotifier := notifier.NewDiscord("news", &config.DiscordNotifierConfig{
+		WebhookUrl: server.URL + "/webhook",
+		OnlyMsg:    true,
+		Truncate:   true,
+	})
+	require.NoError(t, notifier.Inform(textNotifier, "news", result(strconv.Quote(strings.Repeat("a", 2500))), nil, false, logger.Null, nil))
+
+	require.Len(t, chat.bodies, 3)
+	content := chat.bodies[2]["content"].(string)
+	assert.Equal(t, 2000, len([]rune(content)))
+	assert.True(t, strings.HasSuffix(content, "…"))
+}
+
+func TestDiscordNotifier_EmbedLimit(t *testing.T) {
+	chat, server := newChatServer(t)
+
+	description := strings.Repeat("d", 10000)
+	require.NoError(t, notifier.Inform(notifier.NewDiscord("news", &config.DiscordNotifierConfig{
+		WebhookUrl: server.URL + "/webhook",
+		Embed: &config.DiscordEmbedConfig{
+			Title:  strings.Repeat("t", 300),
+			Footer: strings.Repeat("f", 3000),
+		},
+		OnlyMsg: true,
+	}), "news", result(strconv.Quote(description)), nil, false, logger.Null, nil))
+
+	require.Len(t, chat.bodies, 3)
+	var sent strings.Builder
+	for _, body := range chat.bodies {
+		embed := body["embeds"].([]interface{})[0].(map[string]interface{})
+		title := embed["title"].(string)
+		footer := embed["footer"].(map[string]interface{})["text"].(string)
+		assert.Equal(t, 256, len([]rune(title)))
+		assert.Equal(t, 2048, len([]rune(footer)))
+		assert.LessOrEqual(t, len([]rune(title))+len([]rune(footer))+len([]rune(embed["description"].(string))), 6000)
+		sent.WriteString(embed["description"].(string))
+	}
+	assert.Equal(t, description, sent.String())
+}
+
+func TestTelegramParseMode(t *testing.T) {
+	telegramNotifier := notifier.NewTelegramBot("news", &config.TelegramBotConfig{
+		ParseMode: "HTML",
+	})
+
+	chattables := notifier.TelegramChattables(telegramNotifier, 1, "<b>short</b>")
+	require.Len(t, chattables, 1)
+	assert.Equal(t, "HTML", chattables[0].(tgbotapi.MessageConfig).ParseMode)
+
+	long := "<b>" + strings.Repeat("a", 5000) + "</b>"
+	chattables = notifier.TelegramChattables(telegramNotifier, 1, long)
+	require.Len(t, chattables, 2)
+	var sent strings.Builder
+	for _, chattable := range chattables {
+		msg := chattable.(tgbotapi.MessageConfig)
+		assert.Empty(t, msg.ParseMode)
+		sent.WriteString(msg.Text)
+	}
+	assert.Equal(t, long, sent.String())
+}
+
+func TestMatrixNotifier(t *testing.T) {
+	chat, server := newChatServer(t)
+
+	matrixNotifier := notifier.NewMatrix("news", &config.MatrixNotifierConfig{
+		Homeserver:    server.URL,
+		AccessToken:   "token",
+		RoomId:        "!room:example.com",
+		FormattedBody: "<b>{{{title}}}</b>",
+		OnlyMsg:       true,
+	})
+	require.NoError(t, notifier.Inform(matrixNotifier, "news", result(`{"title": "Go 2"}`), nil, false, logger.Null, nil))
+
+	require.Len(t, chat.requests, 1)
+	assert.Equal(t, http.MethodPut, chat.requests[0].Method)
+	assert.Equal(t, "Bearer token", chat.requests[0].Header.Get("Authorization"))
+	assert.True(t, strings.HasPrefix(chat.requests[0].URL.Path, "/_matrix/client/v3/rooms/!room:example.com/send/m.room.message/"))
+	assert.Equal(t, map[string]interface{}{
+		"msgtype":        "m.text",
+		"body":           `{"title": "Go 2"}`,
+		"format":         "org.matrix.custom.html",
+		"formatted_body": "<b>Go 2</b>",
+	}, chat.bodies[0])
+}
+
+func TestMatrixNotifier_Limit(t *testing.T) {
+	chat, server := newChatServer(t)
+
+	// each "я<" is 2 bytes in UTF-8 and 8 bytes in json
+	message := strings.Repeat("я<", 20000)
+	require.NoError(t, notifier.Inform(notifier.NewMatrix("news", &config.MatrixNotifierConfig{
+		Homeserver:    server.URL,
+		RoomId:        "!room:example.com",
+		FormattedBody: strings.Repeat("<br>", 5000),
+		OnlyMsg:       true,
+	}), "news", result(strconv.Quote(message)), nil, false, logger.Null, nil))
+
+	require.Greater(t, len(chat.bodies), 2)
+	var sent strings.Builder
+	for i, body := range chat.bodies {
+		assert.LessOrEqual(t, chat.sizes[i], 65536)
+		sent.WriteString(body["body"].(string))
+	}
+	assert.Equal(t, message, sent.String())
+	assert.True(t, strings.HasSuffix(chat.bodies[0]["formatted_body"].(string), "…"))
+	assert.Nil(t, chat.bodies[1]["formatted_body"])
+}
+
+func TestSMTPNotifier(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	received := make(chan string, 1)
+	recipients := make(chan []string, 1)
+	go serveSMTP(listener, received, recipients)
+
+	addr := listener.Addr().(*net.TCPAddr)
+	smtpNotifier := notifier.NewSMTP("news", &config.SMTPNotifierConfig{
+		Host:    "127.0.0.1",
+		Port:    addr.Port,
+		From:    "Fitter <fitter@example.com>",
+		To:      []string{"team@example.com"},
+		Subject: "New item: {{{title}}}",
+		Text:    "Title: {{{title}}}",
+		HTML:    "<h1>{{{title}}}</h1>",
+	})
+	require.NoError(t, notifier.Inform(smtpNotifier, "news", result(`{"title": "Go 2"}`), nil, false, logger.Null, nil))
+
+	assert.Equal(t, []string{"<fitter@example.com>", "<team@example.com>"}, <-recipients)
+	email := <-received
+	assert.Contains(t, email, "Subject: New item: Go 2\r\n")
+	assert.Contains(t, email, "Content-Type: multipart/alternative; boundary=")
+	assert.Contains(t, email, "Title: Go 2")
+	assert.Contains(t, email, "<h1>Go 2</h1>")
+	assert.Contains(t, email, "From: \"Fitter\" <fitter@example.com>\r\n")
+
+	// address from record can not add headers
+	assert.Error(t, notifier.Inform(notifier.NewSMTP("news", &config.SMTPNotifierConfig{
+		Host: "127.0.0.1",
+		Port: addr.Port,
+		From: "fitter@example.com",
+		To:   []string{"{{{email}}}"},
+	}), "news", result(`{"email": "team@example.com\r\nBcc: all@example.com"}`), nil, false, logger.Null, nil))
+}
+
+// serveSMTP accept one session of the minimal SMTP server
+func serveSMTP(listener net.Listener, received chan<- string, recipients chan<- []string) {
+	conn, err := listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	write := func(line string) {
+		_, _ = conn.Write([]byte(line + "\r\n"))
+	}
+
+	var addresses []string
+	write("220 localhost ESMTP")
+	for {
+		line, errRead := reader.ReadString('\n')
+		if errRead != nil {
+			return
+		}
+		command := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+			write("250 localhost")
+		case strings.HasPrefix(command, "MAIL FROM:"):
+			addresses = append(addresses, strings.TrimPrefix(command, "MAIL FROM:"))
+			write("250 OK")
+		case strings.HasPrefix(command, "RCPT TO:"):
+			addresses = append(addresses, strings.TrimPrefix(command, "RCPT TO:"))
+			write("250 OK")
+		case command == "DATA":
+			write("354 End data with <CR><LF>.<CR><LF>")
+			var data strings.Builder
+			for {
+				dataLine, errData := reader.ReadString('\n')
+				if errData != nil {
+					return
+				}
+				if dataLine == ".\r\n" {
+					break
+				}
+				data.WriteString(dataLine)
+			}
+			recipients <- addresses
+			received <- data.String()
+			write("250 OK")
+		case command == "QUIT":
+			write("221 Bye")
+			return
+		default:
+			write("250 OK")
+		}
+	}
+}
+
+func TestTemplateNotifier(t *testing.T) {
+	chat, server := newChatServer(t)
+
+	slackNotifier := notifier.NewSlack("news", &config.SlackNotifierConfig{
+		WebhookUrl: server.URL,
+		OnlyMsg:    true,
+	})
+	templateNotifier, err := notifier.WithTemplate(slackNotifier, "news", `{{if .Error}}{{.Name}} failed: {{.Error}}{{else}}{{add .Index 1}}. <{{.Result.url}}|{{.Result.title}}>{{end}}`, config.TextTemplate)
+	require.NoError(t, err)
+
+	require.NoError(t, notifier.Inform(templateNotifier, "news", result(`[{"title": "First", "url": "https://a"}, {"title": "Second", "url": "https://b"}]`), nil, true, logger.Null, nil))
+	require.NoError(t, notifier.Inform(templateNotifier, "news", nil, errors.New("timeout"), true, logger.Null, nil))
+
+	require.Len(t, chat.bodies, 3)
+	assert.Equal(t, "1. <https://a|First>", chat.bodies[0]["text"])
+	assert.Equal(t, "2. <https://b|Second>", chat.bodies[1]["text"])
+	assert.Equal(t, "news failed: timeout", chat.bodies[2]["text"])
+}
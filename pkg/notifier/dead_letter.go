@@ -0,0 +1,182 @@
+package notifier
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/PxyUp/fitter/pkg/builder"
+	"github.com/PxyUp/fitter/pkg/logger"
+	"github.com/PxyUp/fitter/pkg/utils"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	deadLetterExt = ".json"
+)
+
+var (
+	errNoDeadLetter = errors.New("dead letter is not configured for notifier")
+
+	deadLetterNameCleaner = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+)
+
+// deadLetter is file with not delivered records
+type deadLetter struct {
+	Name    string          `json:"name"`
+	Records []*deadRecord   `json:"records"`
+	Input   json.RawMessage `json:"input,omitempty"`
+	Error   string          `json:"error"`
+	Time    time.Time       `json:"time"`
+}
+
+type deadRecord struct {
+	Body  json.RawMessage `json:"body,omitempty"`
+	Index *uint32         `json:"index,omitempty"`
+	Error string          `json:"error,omitempty"`
+}
+
+func writeDeadLetter(dir string, name string, records []*singleRecord, input builder.Interfacable, errDelivery error, logger logger.Logger) error {
+	letter := &deadLetter{
+		Name:    name,
+		Records: make([]*deadRecord, len(records)),
+		Time:    time.Now().UTC(),
+	}
+	if errDelivery != nil {
+		letter.Error = errDelivery.Error()
+	}
+	if input != nil {
+		letter.Input = input.Raw()
+	}
+	for i, record := range records {
+		letter.Records[i] = &deadRecord{
+			Body:  record.Body,
+			Index: record.Index,
+		}
+		if record.Error != nil {
+			letter.Records[i].Error = (*record.Error).Error()
+		}
+	}
+
+	return saveDeadLetter(dir, fmt.Sprintf("%d-%s%s", letter.Time.UnixNano(), deadLetterNameCleaner.ReplaceAllString(name, "_"), deadLetterExt), letter, logger)
+}
+
+func saveDeadLetter(dir string, fileName string, letter *deadLetter, logger logger.Logger) error {
+	content, err := json.Marshal(letter)
+	if err != nil {
+		return err
+	}
+
+	filePath, err := utils.WriteFileAtomic(content, fileName, dir, os.ModePerm, logger)
+	if err != nil {
+		return err
+	}
+
+	logger.Infow("notification is written into dead letter", "path", filePath, "records", fmt.Sprintf("%d", len(letter.Records)))
+	return nil
+}
+
+func (l *deadLetter) toRecords() ([]*singleRecord, builder.Interfacable) {
+	records := make([]*singleRecord, len(l.Records))
+	for i, record := range l.Records {
+		records[i] = &singleRecord{
+			Name:  l.Name,
+			Body:  record.Body,
+			Index: record.Index,
+		}
+		if record.Error != "" {
+			errRecord := errors.New(record.Error)
+			records[i].Error = &errRecord
+		}
+	}
+
+	var input builder.Interfacable
+	if len(l.Input) > 0 {
+		input = builder.ToJsonable(l.Input)
+	}
+	return records, input
+}
+
+// Replay send notifications from dead letter again, delivered letters are removed. Return amount of delivered and failed letters
+func Replay(notifier Notifier) (int, int, error) {
+	if group, ok := notifier.(*groupNotifier); ok {
+		notifier = group.Notifier
+	}
+
+	delivery, ok := notifier.(*deliveryNotifier)
+	if !ok || delivery.cfg.DeadLetter == "" {
+		return 0, 0, errNoDeadLetter
+	}
+
+	return delivery.replay()
+}
+
+func (d *deliveryNotifier) replay() (int, int, error) {
+	entries, err := os.ReadDir(d.cfg.DeadLetter)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, 0, nil
+		}
+		return 0, 0, err
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), deadLetterExt) {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+
+	delivered, failed := 0, 0
+	for _, fileName := range names {
+		filePath := path.Join(d.cfg.DeadLetter, fileName)
+		content, errRead := os.ReadFile(filePath)
+		if errRead != nil {
+			return delivered, failed, errRead
+		}
+
+		var letter deadLetter
+		if errRead = json.Unmarshal(content, &letter); errRead != nil {
+			d.GetLogger().Errorw("invalid dead letter", "path", filePath, "error", errRead.Error())
+			failed++
+			continue
+		}
+		if letter.Name != d.name {
+			continue
+		}
+
+		records, input := letter.toRecords()
+		failedRecords, errDeliver := d.deliver(records, input)
+		if errDeliver != nil {
+			d.GetLogger().Errorw("unable to replay dead letter", "path", filePath, "error", errDeliver.Error())
+			failed++
+			if len(failedRecords) < len(records) {
+				letter.Records = letter.Records[:0]
+				for _, record := range failedRecords {
+					deadRec := &deadRecord{Body: record.Body, Index: record.Index}
+					if record.Error != nil {
+						deadRec.Error = (*record.Error).Error()
+					}
+					letter.Records = append(letter.Records, deadRec)
+				}
+				letter.Error = errDeliver.Error()
+				if errSave := saveDeadLetter(d.cfg.DeadLetter, fileName, &letter, d.GetLogger()); errSave != nil {
+					return delivered, failed, errSave
+				}
+			}
+			continue
+		}
+
+		if errRemove := os.Remove(filePath); errRemove != nil {
+			return delivered, failed, errRemove
+		}
+		delivered++
+	}
+
+	return delivered, failed, nil
+}
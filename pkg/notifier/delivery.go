@@ -0,0 +1,298 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/PxyUp/fitter/pkg/builder"
+	"github.com/PxyUp/fitter/pkg/config"
+	"sync"
+	"time"
+)
+
+const (
+	defaultDeliveryBackoff    = time.Second
+	defaultDeliveryMaxBackoff = 30 * time.Second
+	defaultDeliveryQueueSize  = 100
+)
+
+var (
+	_ Notifier      = &deliveryNotifier{}
+	_ batchNotifier = &deliveryNotifier{}
+
+	errShutdown = errors.New("notification is not delivered before shutdown")
+
+	asyncNotifiers []*deliveryNotifier
+	asyncMutex     sync.Mutex
+)
+
+// recordsError is returned by batch notifier when only part of the records is not delivered, other records are not sent again
+type recordsError struct {
+	records []*singleRecord
+	err     error
+}
+
+func (r *recordsError) Error() string {
+	return r.err.Error()
+}
+
+func (r *recordsError) Unwrap() error {
+	return r.err
+}
+
+type deliveryJob struct {
+	records []*singleRecord
+	input   builder.Interfacable
+}
+
+// deliveryNotifier retry notifications, write failed notifications into dead letter and deliver them in background for async mode
+type deliveryNotifier struct {
+	Notifier
+	name string
+	cfg  *config.DeliveryConfig
+
+	queue     chan *deliveryJob
+	pending   sync.WaitGroup
+	startOnce sync.Once
+}
+
+func WithDelivery(notifier Notifier, name string, cfg *config.DeliveryConfig) Notifier {
+	queueSize := defaultDeliveryQueueSize
+	if cfg.QueueSize > 0 {
+		queueSize = int(cfg.QueueSize)
+	}
+
+	return &deliveryNotifier{
+		Notifier: notifier,
+		name:     name,
+		cfg:      cfg,
+		queue:    make(chan *deliveryJob, queueSize),
+	}
+}
+
+func (d *deliveryNotifier) notify(record *singleRecord, input builder.Interfacable) error {
+	return d.notifyBatch([]*singleRecord{record}, input)
+}
+
+func (d *deliveryNotifier) notifyBatch(records []*singleRecord, input builder.Interfacable) error {
+	if d.cfg.Async {
+		d.enqueue(&deliveryJob{
+			records: records,
+			input:   input,
+		})
+		return nil
+	}
+
+	return d.deliverOrDeadLetter(records, input)
+}
+
+func (d *deliveryNotifier) deliverOrDeadLetter(records []*singleRecord, input builder.Interfacable) error {
+	failed, err := d.deliver(records, input)
+	if len(failed) > 0 && d.cfg.DeadLetter != "" {
+		if errDeadLetter := writeDeadLetter(d.cfg.DeadLetter, d.name, failed, input, err, d.GetLogger()); errDeadLetter != nil {
+			d.GetLogger().Errorw("unable to write dead letter", "error", errDeadLetter.Error())
+		}
+	}
+	return err
+}
+
+// deliver notify with retries and return not delivered records
+func (d *deliveryNotifier) deliver(records []*singleRecord, input builder.Interfacable) ([]*singleRecord, error) {
+	if batch, ok := sendsBatch(d.Notifier); ok && len(records) > 1 {
+		err := d.retry(func() error {
+			return batch.notifyBatch(records, input)
+		})
+		var partial *recordsError
+		if errors.As(err, &partial) {
+			return partial.records, err
+		}
+		if err != nil {
+			return records, err
+		}
+		return nil, nil
+	}
+
+	var failed []*singleRecord
+	var errs []error
+	for i, record := range records {
+		err := d.retry(func() error {
+			return d.Notifier.notify(record, input)
+		})
+		if err == nil {
+			continue
+		}
+
+		errs = append(errs, err)
+		if !d.cfg.ContinueOnError {
+			failed = append(failed, records[i:]...)
+			break
+		}
+		failed = append(failed, record)
+	}
+
+	return failed, errors.Join(errs...)
+}
+
+// sendsBatch return batch notifier when records are sent together, wrappers(like template) around notifier without batch support send records one by one,
+// so they are retried per record, otherwise delivered records are sent again and ContinueOnError is ignored
+func sendsBatch(notifier Notifier) (batchNotifier, bool) {
+	if template, ok := notifier.(*templateNotifier); ok {
+		if _, ok = sendsBatch(template.Notifier); !ok {
+			return nil, false
+		}
+	}
+
+	batch, ok := notifier.(batchNotifier)
+	return batch, ok
+}
+
+func (d *deliveryNotifier) retry(send func() error) error {
+	attempts := 1
+	if d.cfg.Attempts > 1 {
+		attempts = int(d.cfg.Attempts)
+	}
+
+	backoff := defaultDeliveryBackoff
+	if d.cfg.Backoff > 0 {
+		backoff = time.Duration(d.cfg.Backoff) * time.Millisecond
+	}
+	maxBackoff := defaultDeliveryMaxBackoff
+	if d.cfg.MaxBackoff > 0 {
+		maxBackoff = time.Duration(d.cfg.MaxBackoff) * time.Millisecond
+	}
+
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if err = send(); err == nil {
+			return nil
+		}
+		// delivered records are not sent again
+		var partial *recordsError
+		if attempt == attempts || errors.As(err, &partial) {
+			break
+		}
+
+		d.GetLogger().Infow("notification failed, retry", "attempt", fmt.Sprintf("%d", attempt), "delay", backoff.String(), "error", err.Error())
+		time.Sleep(backoff)
+		backoff *= 2
+		if backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+
+	return err
+}
+
+func (d *deliveryNotifier) enqueue(job *deliveryJob) {
+	d.startOnce.Do(func() {
+		asyncMutex.Lock()
+		asyncNotifiers = append(asyncNotifiers, d)
+		asyncMutex.Unlock()
+
+		go d.worker()
+	})
+
+	d.pending.Add(1)
+	select {
+	case d.queue <- job:
+	default:
+		d.GetLogger().Infow("notification queue is full, waiting")
+		d.queue <- job
+	}
+}
+
+func (d *deliveryNotifier) worker() {
+	for job := range d.queue {
+		if err := d.deliverOrDeadLetter(job.records, job.input); err != nil {
+			d.GetLogger().Errorw("cannot notify about result", "error", err.Error())
+		}
+		d.pending.Done()
+	}
+}
+
+// shutdown wait for queued notifications, notifications which are still in queue after ctx is done are written into dead letter
+func (d *deliveryNotifier) shutdown(ctx context.Context) {
+	done := make(chan struct{})
+	go func() {
+		d.pending.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return
+	case <-ctx.Done():
+	}
+
+	for {
+		select {
+		case job := <-d.queue:
+			if d.cfg.DeadLetter != "" {
+				if err := writeDeadLetter(d.cfg.DeadLetter, d.name, job.records, job.input, errShutdown, d.GetLogger()); err != nil {
+					d.GetLogger().Errorw("unable to write dead letter", "error", err.Error())
+				}
+			} else {
+				d.GetLogger().Errorw("notification is dropped on shutdown", "records", fmt.Sprintf("%d", len(job.records)), "error", errShutdown.Error())
+			}
+			d.pending.Done()
+		default:
+			return
+		}
+	}
+}
+
+// close stop background worker, notifier must not be used after close
+func (d *deliveryNotifier) close() {
+	asyncMutex.Lock()
+	for i, notifier := range asyncNotifiers {
+		if notifier == d {
+			asyncNotifiers = append(asyncNotifiers[:i], asyncNotifiers[i+1:]...)
+			break
+		}
+	}
+	asyncMutex.Unlock()
+
+	close(d.queue)
+}
+
+// Close send grouped records and wait for async notifications only of the notifier(and notifiers wrapped by it) until ctx is done,
+// background workers of the notifier are stopped, so notifier must not be used after Close
+func Close(ctx context.Context, notifier Notifier) {
+	for notifier != nil {
+		switch n := notifier.(type) {
+		case *groupNotifier:
+			n.shutdown(ctx)
+			n.close()
+			notifier = n.Notifier
+		case *deliveryNotifier:
+			n.shutdown(ctx)
+			n.close()
+			notifier = n.Notifier
+		case *templateNotifier:
+			notifier = n.Notifier
+		default:
+			return
+		}
+	}
+}
+
+// Shutdown send grouped records and wait for async notifications until ctx is done, not delivered notifications are written into dead letter
+func Shutdown(ctx context.Context) {
+	groupMutex.Lock()
+	groups := make([]*groupNotifier, len(groupNotifiers))
+	copy(groups, groupNotifiers)
+	groupMutex.Unlock()
+
+	for _, g := range groups {
+		g.shutdown(ctx)
+	}
+
+	asyncMutex.Lock()
+	notifiers := make([]*deliveryNotifier, len(asyncNotifiers))
+	copy(notifiers, asyncNotifiers)
+	asyncMutex.Unlock()
+
+	for _, d := range notifiers {
+		d.shutdown(ctx)
+	}
+}
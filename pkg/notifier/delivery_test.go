@@ -0,0 +1,189 @@
+package notifier_test
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/PxyUp/fitter/pkg/config"
+	"github.com/PxyUp/fitter/pkg/logger"
+	"github.com/PxyUp/fitter/pkg/notifier"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+	"time"
+)
+
+// flakyServer fail first requests and accept rest of them
+type flakyServer struct {
+	mutex    sync.Mutex
+	failures int
+	fail     func(text string) bool
+	texts    []string
+}
+
+func newFlakyServer(t *testing.T, failures int) (*flakyServer, *httptest.Server) {
+	flaky := &flakyServer{
+		failures: failures,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		var decoded map[string]interface{}
+		require.NoError(t, json.Unmarshal(body, &decoded))
+		text, _ := decoded["text"].(string)
+
+		flaky.mutex.Lock()
+		defer flaky.mutex.Unlock()
+		if flaky.failures > 0 || (flaky.fail != nil && flaky.fail(text)) {
+			flaky.failures--
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		flaky.texts = append(flaky.texts, text)
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+	return flaky, server
+}
+
+func (f *flakyServer) received() []string {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return append([]string{}, f.texts...)
+}
+
+func slackDelivery(server *httptest.Server, cfg *config.DeliveryConfig) notifier.Notifier {
+	return notifier.WithDelivery(notifier.NewSlack("news", &config.SlackNotifierConfig{
+		WebhookUrl: server.URL,
+		OnlyMsg:    true,
+	}), "news", cfg)
+}
+
+func TestDeliveryRetry(t *testing.T) {
+	flaky, server := newFlakyServer(t, 2)
+
+	require.NoError(t, notifier.Inform(slackDelivery(server, &config.DeliveryConfig{
+		Attempts: 3,
+		Backoff:  1,
+	}), "news", result(`"hello"`), nil, false, logger.Null, nil))
+	assert.Equal(t, []string{"hello"}, flaky.received())
+
+	flaky.failures = 2
+	assert.Error(t, notifier.Inform(slackDelivery(server, &config.DeliveryConfig{
+		Attempts: 2,
+		Backoff:  1,
+	}), "news", result(`"world"`), nil, false, logger.Null, nil))
+	assert.Equal(t, []string{"hello"}, flaky.received())
+}
+
+func TestDeliveryDeadLetterReplay(t *testing.T) {
+	flaky, server := newFlakyServer(t, 0)
+	flaky.fail = func(text string) bool {
+		return text == "b"
+	}
+	dir := t.TempDir()
+
+	deliveryNotifier := slackDelivery(server, &config.DeliveryConfig{
+		ContinueOnError: true,
+		DeadLetter:      dir,
+	})
+	assert.Error(t, notifier.Inform(deliveryNotifier, "news", result(`["a", "b", "c"]`), nil, true, logger.Null, nil))
+	assert.Equal(t, []string{"a", "c"}, flaky.received())
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	delivered, failed, err := notifier.Replay(deliveryNotifier)
+	require.NoError(t, err)
+	assert.Equal(t, 0, delivered)
+	assert.Equal(t, 1, failed)
+
+	flaky.mutex.Lock()
+	flaky.fail = nil
+	flaky.mutex.Unlock()
+
+	delivered, failed, err = notifier.Replay(deliveryNotifier)
+	require.NoError(t, err)
+	assert.Equal(t, 1, delivered)
+	assert.Equal(t, 0, failed)
+	assert.Equal(t, []string{"a", "c", "b"}, flaky.received())
+
+	entries, err = os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 0)
+
+	_, _, err = notifier.Replay(notifier.NewSlack("news", &config.SlackNotifierConfig{}))
+	assert.Error(t, err)
+}
+
+func TestDeliveryTemplate(t *testing.T) {
+	flaky, server := newFlakyServer(t, 0)
+	flaky.fail = func(text string) bool {
+		return text == "item b"
+	}
+	dir := t.TempDir()
+
+	templateNotifier, err := notifier.WithTemplate(notifier.NewSlack("news", &config.SlackNotifierConfig{
+		WebhookUrl: server.URL,
+		OnlyMsg:    true,
+	}), "news", "item {{ .Result }}", config.TextTemplate)
+	require.NoError(t, err)
+
+	assert.Error(t, notifier.Inform(notifier.WithDelivery(templateNotifier, "news", &config.DeliveryConfig{
+		Attempts:        2,
+		Backoff:         1,
+		ContinueOnError: true,
+		DeadLetter:      dir,
+	}), "news", result(`["a", "b", "c"]`), nil, true, logger.Null, nil))
+	assert.Equal(t, []string{"item a", "item c"}, flaky.received())
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestDeliveryAsync(t *testing.T) {
+	flaky, server := newFlakyServer(t, 0)
+
+	deliveryNotifier := slackDelivery(server, &config.DeliveryConfig{
+		Async: true,
+	})
+	require.NoError(t, notifier.Inform(deliveryNotifier, "news", result(`["a", "b"]`), nil, true, logger.Null, nil))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	notifier.Shutdown(ctx)
+
+	assert.Equal(t, []string{"a", "b"}, flaky.received())
+}
+
+func TestDeliveryClose(t *testing.T) {
+	flaky, server := newFlakyServer(t, 0)
+
+	closed := slackDelivery(server, &config.DeliveryConfig{
+		Async: true,
+	})
+	other := slackDelivery(server, &config.DeliveryConfig{
+		Async: true,
+	})
+	require.NoError(t, notifier.Inform(closed, "news", result(`["a"]`), nil, true, logger.Null, nil))
+	require.NoError(t, notifier.Inform(other, "news", result(`["b"]`), nil, true, logger.Null, nil))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	notifier.Close(ctx, closed)
+	assert.Contains(t, flaky.received(), "a")
+
+	async := notifier.AsyncNotifiers()
+	assert.NotContains(t, async, closed)
+	assert.Contains(t, async, other)
+
+	notifier.Shutdown(ctx)
+	assert.ElementsMatch(t, []string{"a", "b"}, flaky.received())
+}
@@ -0,0 +1,125 @@
+package notifier
+
+import (
+	"github.com/PxyUp/fitter/pkg/builder"
+	"github.com/PxyUp/fitter/pkg/config"
+	"github.com/PxyUp/fitter/pkg/logger"
+	"net/http"
+	"unicode/utf8"
+)
+
+const (
+	discordContentLimit     = 2000
+	discordDescriptionLimit = 4096
+	discordTitleLimit       = 256
+	discordFooterLimit      = 2048
+	// discordEmbedLimit is limit of all texts of the embed together
+	discordEmbedLimit = 6000
+)
+
+var (
+	_ Notifier = &discordNotifier{}
+)
+
+type discordNotifier struct {
+	logger logger.Logger
+	name   string
+	cfg    *config.DiscordNotifierConfig
+}
+
+type discordMessage struct {
+	Content   string          `json:"content,omitempty"`
+	Username  string          `json:"username,omitempty"`
+	AvatarUrl string          `json:"avatar_url,omitempty"`
+	Embeds    []*discordEmbed `json:"embeds,omitempty"`
+}
+
+type discordEmbed struct {
+	Title       string              `json:"title,omitempty"`
+	Description string              `json:"description,omitempty"`
+	Url         string              `json:"url,omitempty"`
+	Color       int                 `json:"color,omitempty"`
+	Footer      *discordEmbedFooter `json:"footer,omitempty"`
+}
+
+type discordEmbedFooter struct {
+	Text string `json:"text"`
+}
+
+func NewDiscord(name string, cfg *config.DiscordNotifierConfig) *discordNotifier {
+	return &discordNotifier{
+		logger: logger.Null,
+		name:   name,
+		cfg:    cfg,
+	}
+}
+
+func (d *discordNotifier) WithLogger(logger logger.Logger) *discordNotifier {
+	d.logger = logger
+	return d
+}
+
+func (d *discordNotifier) GetLogger() logger.Logger {
+	return d.logger
+}
+
+func (d *discordNotifier) notify(record *singleRecord, input builder.Interfacable) error {
+	msg, err := recordMessage(record, d.cfg.OnlyMsg, d.cfg.Pretty)
+	if err != nil {
+		d.logger.Errorw("cant create message", "error", err.Error())
+		return err
+	}
+
+	url := formatWithRecord(d.cfg.WebhookUrl, record, input)
+
+	if d.cfg.Embed == nil {
+		for _, part := range messageParts(msg, discordContentLimit, d.cfg.Truncate) {
+			if err = d.send(url, &discordMessage{Content: part}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	title := messageParts(formatWithRecord(d.cfg.Embed.Title, record, input), discordTitleLimit, true)[0]
+	footer := ""
+	if d.cfg.Embed.Footer != "" {
+		footer = messageParts(formatWithRecord(d.cfg.Embed.Footer, record, input), discordFooterLimit, true)[0]
+	}
+	descriptionLimit := discordEmbedLimit - utf8.RuneCountInString(title) - utf8.RuneCountInString(footer)
+	if descriptionLimit > discordDescriptionLimit {
+		descriptionLimit = discordDescriptionLimit
+	}
+
+	for _, part := range messageParts(msg, descriptionLimit, d.cfg.Truncate) {
+		embed := &discordEmbed{
+			Title:       title,
+			Description: part,
+			Url:         formatWithRecord(d.cfg.Embed.Url, record, input),
+			Color:       d.cfg.Embed.Color,
+		}
+		if footer != "" {
+			embed.Footer = &discordEmbedFooter{
+				Text: footer,
+			}
+		}
+
+		if err = d.send(url, &discordMessage{Embeds: []*discordEmbed{embed}}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (d *discordNotifier) send(url string, msg *discordMessage) error {
+	msg.Username = d.cfg.Username
+	msg.AvatarUrl = d.cfg.AvatarUrl
+
+	err := sendJSON(http.MethodPost, url, nil, msg, d.cfg.Timeout)
+	if err != nil {
+		d.logger.Errorw("unable to send discord message", "error", err.Error())
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package notifier
+
+import (
+	"encoding/json"
+	"github.com/PxyUp/fitter/pkg/builder"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/segmentio/kafka-go"
+)
+
+// testQueueMessages create messages for bodies like they are sent by item
+func testQueueMessages(name string, bodies []string, keyPath string) ([]*queueMessage, error) {
+	records := make([]*singleRecord, len(bodies))
+	for i, body := range bodies {
+		index := uint32(i)
+		records[i] = &singleRecord{
+			Name:  name,
+			Body:  json.RawMessage(body),
+			Index: &index,
+		}
+	}
+	return toQueueMessages(records, keyPath)
+}
+
+// KafkaMessages build kafka messages for bodies without connection to brokers
+func KafkaMessages(k *kafkaNotifier, bodies []string, input builder.Interfacable) ([]kafka.Message, error) {
+	messages, err := testQueueMessages(k.name, bodies, k.cfg.KeyPath)
+	if err != nil {
+		return nil, err
+	}
+	return k.messages(messages, input), nil
+}
+
+// AMQPPublishings build routing keys and amqp messages for bodies without connection to broker
+func AMQPPublishings(a *amqpNotifier, bodies []string, input builder.Interfacable) ([]string, []amqp.Publishing, error) {
+	messages, err := testQueueMessages(a.name, bodies, a.cfg.KeyPath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	routingKeys := make([]string, len(messages))
+	publishings := make([]amqp.Publishing, len(messages))
+	for i, msg := range messages {
+		routingKeys[i], publishings[i] = a.publishing(msg, input)
+	}
+	return routingKeys, publishings, nil
+}
+
+// TelegramChattables return messages which are sent to the chat without connection to telegram
+func TelegramChattables(t *telegramBot, id int64, msg string) []tgbotapi.Chattable {
+	return t.chattables(id, msg, nil, false)
+}
+
+// AsyncNotifiers return async notifiers which are sent on Shutdown
+func AsyncNotifiers() (async []Notifier) {
+	asyncMutex.Lock()
+	defer asyncMutex.Unlock()
+
+	for _, d := range asyncNotifiers {
+		async = append(async, d)
+	}
+	return async
+}
+
+// GroupNotifiers return batch and digest notifiers which are sent on Shutdown
+func GroupNotifiers() (groups []Notifier) {
+	groupMutex.Lock()
+	defer groupMutex.Unlock()
+
+	for _, g := range groupNotifiers {
+		groups = append(groups, g)
+	}
+	return groups
+}
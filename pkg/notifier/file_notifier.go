@@ -1,16 +1,19 @@
 package notifier
 
 import (
+	"bytes"
 	"encoding/json"
 	"github.com/PxyUp/fitter/pkg/builder"
 	"github.com/PxyUp/fitter/pkg/config"
 	"github.com/PxyUp/fitter/pkg/logger"
+	"github.com/PxyUp/fitter/pkg/storage"
 	"github.com/PxyUp/fitter/pkg/utils"
 	"os"
 )
 
 var (
-	_ Notifier = &fileNotifier{}
+	_ Notifier      = &fileNotifier{}
+	_ batchNotifier = &fileNotifier{}
 )
 
 type fileNotifier struct {
@@ -20,6 +23,10 @@ type fileNotifier struct {
 }
 
 func (f *fileNotifier) notify(record *singleRecord, input builder.Interfacable) error {
+	if f.cfg.Format != "" {
+		return f.notifyBatch([]*singleRecord{record}, input)
+	}
+
 	destinationFileName := formatWithRecord(f.cfg.FileName, record, input)
 	destinationPath := formatWithRecord(f.cfg.Path, record, input)
 
@@ -30,13 +37,7 @@ func (f *fileNotifier) notify(record *singleRecord, input builder.Interfacable)
 			return err
 		}
 
-		_, err = utils.CreateFileWithContent(bb, destinationFileName, destinationPath, os.ModePerm, f.cfg.Append, f.logger)
-		if err != nil {
-			f.logger.Errorw("cannot save result to file", "path", destinationPath, "file_name", destinationFileName, "error", err.Error())
-			return err
-		}
-
-		return nil
+		return f.save(f.line(bb), destinationFileName, destinationPath, record, input)
 	}
 	content := f.cfg.Content
 	if len(f.cfg.Raw) > 0 {
@@ -44,7 +45,29 @@ func (f *fileNotifier) notify(record *singleRecord, input builder.Interfacable)
 	}
 
 	content = formatWithRecord(content, record, input)
-	_, err := utils.CreateFileWithContent([]byte(content), destinationFileName, destinationPath, os.ModePerm, f.cfg.Append, f.logger)
+	return f.save(f.line([]byte(content)), destinationFileName, destinationPath, record, input)
+}
+
+// line end content with new line for Append, so appended records are not written back to back
+func (f *fileNotifier) line(content []byte) []byte {
+	if !f.cfg.Append || bytes.HasSuffix(content, []byte("\n")) {
+		return content
+	}
+
+	return append(content, '\n')
+}
+
+func (f *fileNotifier) save(content []byte, destinationFileName string, destinationPath string, record *singleRecord, input builder.Interfacable) error {
+	var err error
+	if f.cfg.S3 != nil {
+		if f.cfg.Append {
+			f.logger.Errorw("cannot append result to s3 object", "error", storage.ErrS3Append.Error())
+			return storage.ErrS3Append
+		}
+		_, err = storage.Upload(f.cfg.S3, storage.Key(destinationPath, destinationFileName), content, formatWithRecord(f.cfg.ContentType, record, input), f.cfg.Gzip, input, f.logger)
+	} else {
+		_, err = utils.CreateFileWithContent(content, destinationFileName, destinationPath, os.ModePerm, f.cfg.Append, f.logger)
+	}
 	if err != nil {
 		f.logger.Errorw("cannot save result to file", "path", destinationPath, "file_name", destinationFileName, "error", err.Error())
 		return err
@@ -53,6 +76,74 @@ func (f *fileNotifier) notify(record *singleRecord, input builder.Interfacable)
 	return nil
 }
 
+type fileDestination struct {
+	fileName string
+	path     string
+}
+
+// notifyBatch write all records with same destination at once, records with error are skipped for csv and parquet
+func (f *fileNotifier) notifyBatch(records []*singleRecord, input builder.Interfacable) error {
+	if f.cfg.Format == "" {
+		for _, record := range records {
+			if err := f.notify(record, input); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	var destinations []fileDestination
+	rows := make(map[fileDestination][]json.RawMessage)
+	for _, record := range records {
+		row, err := f.row(record, input)
+		if err != nil {
+			f.logger.Errorw("cannot unmarshal result", "error", err.Error())
+			return err
+		}
+		if row == nil {
+			continue
+		}
+
+		destination := fileDestination{
+			fileName: formatWithRecord(f.cfg.FileName, record, input),
+			path:     formatWithRecord(f.cfg.Path, record, input),
+		}
+		if _, ok := rows[destination]; !ok {
+			destinations = append(destinations, destination)
+		}
+		rows[destination] = append(rows[destination], row)
+	}
+
+	for _, destination := range destinations {
+		_, err := storage.WriteRecords(f.cfg, destination.fileName, destination.path, rows[destination], utils.Format(f.cfg.ContentType, nil, nil, input), input, f.logger)
+		if err != nil {
+			f.logger.Errorw("cannot save result to file", "path", destination.path, "file_name", destination.fileName, "error", err.Error())
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (f *fileNotifier) row(record *singleRecord, input builder.Interfacable) (json.RawMessage, error) {
+	if f.cfg.Content != "" || len(f.cfg.Raw) > 0 {
+		content := f.cfg.Content
+		if len(f.cfg.Raw) > 0 {
+			content = string(f.cfg.Raw)
+		}
+		return json.RawMessage(formatWithRecord(content, record, input)), nil
+	}
+
+	if f.cfg.Format == config.JSONLFormat {
+		return json.Marshal(record)
+	}
+
+	if record.Error != nil {
+		return nil, nil
+	}
+	return record.Body, nil
+}
+
 func (o *fileNotifier) GetLogger() logger.Logger {
 	return o.logger
 }
@@ -0,0 +1,66 @@
+package notifier_test
+
+import (
+	"github.com/PxyUp/fitter/pkg/config"
+	"github.com/PxyUp/fitter/pkg/logger"
+	"github.com/PxyUp/fitter/pkg/notifier"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path"
+	"testing"
+)
+
+func TestFileNotifierFormat(t *testing.T) {
+	dir := t.TempDir()
+	fileNotifier := notifier.NewFile("products", &config.FileStorageField{
+		FileName: "{{{category}}}.csv",
+		Path:     dir,
+		Format:   config.CSVFormat,
+		Columns: []*config.FileColumn{
+			{Name: "sku", Path: "sku"},
+			{Name: "price", Path: "price"},
+		},
+	})
+
+	require.NoError(t, notifier.Inform(fileNotifier, "products", result(`[
+		{"sku": "A-1", "price": 10, "category": "books"},
+		{"sku": "B-2", "price": 20, "category": "games"},
+		{"sku": "C-3", "price": 30, "category": "books"}
+	]`), nil, true, logger.Null, nil))
+
+	content, err := os.ReadFile(path.Join(dir, "books.csv"))
+	require.NoError(t, err)
+	assert.Equal(t, "sku,price\nA-1,10\nC-3,30\n", string(content))
+
+	content, err = os.ReadFile(path.Join(dir, "games.csv"))
+	require.NoError(t, err)
+	assert.Equal(t, "sku,price\nB-2,20\n", string(content))
+}
+
+func TestFileNotifierAppend(t *testing.T) {
+	dir := t.TempDir()
+	fileNotifier := notifier.NewFile("products", &config.FileStorageField{
+		FileName: "products.jsonl",
+		Path:     dir,
+		Append:   true,
+	})
+	contentNotifier := notifier.NewFile("products", &config.FileStorageField{
+		FileName: "skus.txt",
+		Path:     dir,
+		Append:   true,
+		Content:  "{{{sku}}}",
+	})
+
+	for _, n := range []notifier.Notifier{fileNotifier, contentNotifier} {
+		require.NoError(t, notifier.Inform(n, "products", result(`[{"sku": "A-1"}, {"sku": "B-2"}]`), nil, true, logger.Null, nil))
+	}
+
+	content, err := os.ReadFile(path.Join(dir, "products.jsonl"))
+	require.NoError(t, err)
+	assert.Equal(t, `{"name":"products","body":{"sku":"A-1"},"index":0}`+"\n"+`{"name":"products","body":{"sku":"B-2"},"index":1}`+"\n", string(content))
+
+	content, err = os.ReadFile(path.Join(dir, "skus.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "A-1\nB-2\n", string(content))
+}
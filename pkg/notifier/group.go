@@ -0,0 +1,225 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/PxyUp/fitter/pkg/builder"
+	"github.com/PxyUp/fitter/pkg/config"
+	"sync"
+	"time"
+)
+
+const (
+	defaultDigestInterval = time.Hour
+)
+
+var (
+	_ Notifier      = &groupNotifier{}
+	_ batchNotifier = &groupNotifier{}
+
+	groupNotifiers []*groupNotifier
+	groupMutex     sync.Mutex
+)
+
+// groupNotifier collect records and send them like one notification with array of the bodies. Records with error are sent without grouping
+type groupNotifier struct {
+	Notifier
+	name string
+	// size max amount of records in the group, unlimited if 0
+	size int
+	// wait return delay before not full group is sent, group is sent at end of each call if nil
+	wait func(now time.Time) time.Duration
+
+	mutex        sync.Mutex
+	buffer       []json.RawMessage
+	input        builder.Interfacable
+	timer        *time.Timer
+	generation   uint64
+	sendMutex    sync.Mutex
+	registerOnce sync.Once
+}
+
+func WithBatch(notifier Notifier, name string, cfg *config.BatchConfig) Notifier {
+	group := &groupNotifier{
+		Notifier: notifier,
+		name:     name,
+		size:     int(cfg.Size),
+	}
+	if cfg.MaxWait > 0 {
+		maxWait := time.Duration(cfg.MaxWait) * time.Millisecond
+		group.wait = func(now time.Time) time.Duration {
+			return maxWait
+		}
+	}
+	return group
+}
+
+func WithDigest(notifier Notifier, name string, cfg *config.DigestConfig) Notifier {
+	interval := defaultDigestInterval
+	if cfg.Interval > 0 {
+		interval = time.Duration(cfg.Interval) * time.Second
+	}
+
+	return &groupNotifier{
+		Notifier: notifier,
+		name:     name,
+		size:     int(cfg.MaxItems),
+		wait: func(now time.Time) time.Duration {
+			return now.Truncate(interval).Add(interval).Sub(now)
+		},
+	}
+}
+
+func (g *groupNotifier) notify(record *singleRecord, input builder.Interfacable) error {
+	return g.notifyBatch([]*singleRecord{record}, input)
+}
+
+func (g *groupNotifier) notifyBatch(records []*singleRecord, input builder.Interfacable) error {
+	var errs []error
+
+	var bodies []json.RawMessage
+	for _, record := range records {
+		if record.Error != nil {
+			if err := g.send(func() error { return g.Notifier.notify(record, input) }); err != nil {
+				errs = append(errs, err)
+			}
+			continue
+		}
+		bodies = append(bodies, record.Body)
+	}
+
+	for _, chunk := range g.add(bodies, input) {
+		if err := g.sendChunk(chunk, input); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// add put bodies into buffer and return groups which are ready for sending
+func (g *groupNotifier) add(bodies []json.RawMessage, input builder.Interfacable) [][]json.RawMessage {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	g.buffer = append(g.buffer, bodies...)
+	g.input = input
+
+	var chunks [][]json.RawMessage
+	for g.size > 0 && len(g.buffer) >= g.size {
+		chunks = append(chunks, g.buffer[:g.size:g.size])
+		g.buffer = g.buffer[g.size:]
+	}
+
+	if g.wait == nil {
+		if len(g.buffer) > 0 {
+			chunks = append(chunks, g.buffer)
+		}
+		g.buffer = nil
+		return chunks
+	}
+
+	if len(g.buffer) == 0 {
+		g.stopTimer()
+		return chunks
+	}
+
+	if g.timer == nil {
+		g.registerOnce.Do(func() {
+			groupMutex.Lock()
+			groupNotifiers = append(groupNotifiers, g)
+			groupMutex.Unlock()
+		})
+
+		g.generation++
+		generation := g.generation
+		delay := g.wait(time.Now())
+		g.GetLogger().Debugw("notification is delayed", "delay", delay.String())
+		g.timer = time.AfterFunc(delay, func() {
+			g.flush(generation)
+		})
+	}
+
+	return chunks
+}
+
+func (g *groupNotifier) stopTimer() {
+	if g.timer != nil {
+		g.timer.Stop()
+		g.timer = nil
+	}
+}
+
+// take return buffered bodies and reset buffer
+func (g *groupNotifier) take() ([]json.RawMessage, builder.Interfacable) {
+	g.stopTimer()
+	chunk, input := g.buffer, g.input
+	g.buffer = nil
+	return chunk, input
+}
+
+func (g *groupNotifier) flush(generation uint64) {
+	g.mutex.Lock()
+	if generation != g.generation || g.timer == nil {
+		g.mutex.Unlock()
+		return
+	}
+	chunk, input := g.take()
+	g.mutex.Unlock()
+
+	if err := g.sendChunk(chunk, input); err != nil {
+		g.GetLogger().Errorw("cannot notify about result", "error", err.Error())
+	}
+}
+
+// shutdown send buffered records without waiting
+func (g *groupNotifier) shutdown(ctx context.Context) {
+	g.mutex.Lock()
+	chunk, input := g.take()
+	g.mutex.Unlock()
+
+	if err := g.sendChunk(chunk, input); err != nil {
+		g.GetLogger().Errorw("cannot notify about result", "error", err.Error())
+	}
+}
+
+// close remove notifier from notifiers which are sent on Shutdown
+func (g *groupNotifier) close() {
+	groupMutex.Lock()
+	defer groupMutex.Unlock()
+
+	for i, notifier := range groupNotifiers {
+		if notifier == g {
+			groupNotifiers = append(groupNotifiers[:i], groupNotifiers[i+1:]...)
+			return
+		}
+	}
+}
+
+func (g *groupNotifier) sendChunk(chunk []json.RawMessage, input builder.Interfacable) error {
+	if len(chunk) == 0 {
+		return nil
+	}
+
+	body, err := json.Marshal(chunk)
+	if err != nil {
+		return err
+	}
+
+	g.GetLogger().Debugw("send grouped notification", "size", fmt.Sprintf("%d", len(chunk)))
+	return g.send(func() error {
+		return g.Notifier.notify(&singleRecord{
+			Name: g.name,
+			Body: body,
+		}, input)
+	})
+}
+
+// send keep order of the notifications
+func (g *groupNotifier) send(notify func() error) error {
+	g.sendMutex.Lock()
+	defer g.sendMutex.Unlock()
+	return notify()
+}
@@ -0,0 +1,107 @@
+package notifier_test
+
+import (
+	"context"
+	"errors"
+	"github.com/PxyUp/fitter/pkg/config"
+	"github.com/PxyUp/fitter/pkg/logger"
+	"github.com/PxyUp/fitter/pkg/notifier"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func chatTexts(chat *chatServer) []string {
+	chat.mutex.Lock()
+	defer chat.mutex.Unlock()
+
+	texts := make([]string, len(chat.bodies))
+	for i, body := range chat.bodies {
+		texts[i], _ = body["text"].(string)
+	}
+	return texts
+}
+
+func TestBatchNotifier(t *testing.T) {
+	chat, server := newChatServer(t)
+	slack := notifier.NewSlack("news", &config.SlackNotifierConfig{
+		WebhookUrl: server.URL,
+		OnlyMsg:    true,
+	})
+
+	batchNotifier := notifier.WithBatch(slack, "news", &config.BatchConfig{
+		Size: 2,
+	})
+	require.NoError(t, notifier.Inform(batchNotifier, "news", result(`["a", "b", "c", "d", "e"]`), nil, true, logger.Null, nil))
+	assert.Equal(t, []string{`["a","b"]`, `["c","d"]`, `["e"]`}, chatTexts(chat))
+
+	waitNotifier := notifier.WithBatch(slack, "news", &config.BatchConfig{
+		Size:    3,
+		MaxWait: 50,
+	})
+	require.NoError(t, notifier.Inform(waitNotifier, "news", result(`["f"]`), nil, true, logger.Null, nil))
+	require.NoError(t, notifier.Inform(waitNotifier, "news", result(`["g"]`), nil, true, logger.Null, nil))
+	assert.Len(t, chatTexts(chat), 3)
+
+	assert.Eventually(t, func() bool {
+		return len(chatTexts(chat)) == 4
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, `["f","g"]`, chatTexts(chat)[3])
+}
+
+func TestDigestNotifier(t *testing.T) {
+	chat, server := newChatServer(t)
+	digestNotifier := notifier.WithDigest(notifier.NewSlack("news", &config.SlackNotifierConfig{
+		WebhookUrl: server.URL,
+		OnlyMsg:    true,
+	}), "news", &config.DigestConfig{
+		Interval: 3600,
+	})
+
+	require.NoError(t, notifier.Inform(digestNotifier, "news", result(`[{"id": 1}, {"id": 2}]`), nil, true, logger.Null, nil))
+	require.NoError(t, notifier.Inform(digestNotifier, "news", result(`{"id": 3}`), nil, false, logger.Null, nil))
+	assert.Len(t, chatTexts(chat), 0)
+
+	require.NoError(t, notifier.Inform(digestNotifier, "news", nil, errors.New("timeout"), false, logger.Null, nil))
+	assert.Equal(t, []string{"timeout"}, chatTexts(chat))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	notifier.Shutdown(ctx)
+
+	assert.Equal(t, []string{"timeout", `[{"id":1},{"id":2},{"id":3}]`}, chatTexts(chat))
+}
+
+func TestCloseNotifier(t *testing.T) {
+	chat, server := newChatServer(t)
+	slack := notifier.NewSlack("news", &config.SlackNotifierConfig{
+		WebhookUrl: server.URL,
+		OnlyMsg:    true,
+	})
+
+	delivery := notifier.WithDelivery(slack, "news", &config.DeliveryConfig{
+		Async: true,
+	})
+	closed := notifier.WithDigest(delivery, "news", &config.DigestConfig{
+		Interval: 3600,
+	})
+	other := notifier.WithDigest(slack, "other", &config.DigestConfig{
+		Interval: 3600,
+	})
+	require.NoError(t, notifier.Inform(closed, "news", result(`["a"]`), nil, true, logger.Null, nil))
+	require.NoError(t, notifier.Inform(other, "other", result(`["b"]`), nil, true, logger.Null, nil))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	notifier.Close(ctx, closed)
+	assert.Equal(t, []string{`["a"]`}, chatTexts(chat))
+
+	groups := notifier.GroupNotifiers()
+	assert.NotContains(t, groups, closed)
+	assert.Contains(t, groups, other)
+	assert.NotContains(t, notifier.AsyncNotifiers(), delivery)
+
+	notifier.Shutdown(ctx)
+	assert.Equal(t, []string{`["a"]`, `["b"]`}, chatTexts(chat))
+}
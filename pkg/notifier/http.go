@@ -3,15 +3,33 @@ package notifier
 import (
 	"bytes"
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"github.com/PxyUp/fitter/pkg/builder"
 	"github.com/PxyUp/fitter/pkg/config"
 	"github.com/PxyUp/fitter/pkg/http_client"
 	"github.com/PxyUp/fitter/pkg/logger"
+	"io"
 	"net/http"
+	"strconv"
 	"time"
 )
 
+const (
+	defaultSignatureHeader = "X-Signature"
+	defaultHttpTimeout     = 2 * time.Minute
+	jsonContentType        = "application/json"
+)
+
+var (
+	errUnknownSignatureEncoding = errors.New("unknown signature encoding")
+)
+
 type httpNotifier struct {
 	logger logger.Logger
 	name   string
@@ -19,7 +37,7 @@ type httpNotifier struct {
 }
 
 func (h *httpNotifier) notify(record *singleRecord, input builder.Interfacable) error {
-	bb, err := json.Marshal(record)
+	bb, err := h.body(record, input)
 	if err != nil {
 		h.logger.Errorw("cant unmarshal request body", "error", err.Error())
 		return err
@@ -36,13 +54,33 @@ func (h *httpNotifier) notify(record *singleRecord, input builder.Interfacable)
 		req.Header.Add(k, formatWithRecord(v, record, input))
 	}
 
+	if h.cfg.ContentType != "" {
+		req.Header.Set("Content-Type", h.cfg.ContentType)
+	} else if req.Header.Get("Content-Type") == "" {
+		req.Header.Set("Content-Type", jsonContentType)
+	}
+
+	if h.cfg.Signature != nil {
+		err = h.sign(req, bb, record, input, time.Now())
+		if err != nil {
+			h.logger.Errorw("cant sign request", "error", err.Error())
+			return err
+		}
+	}
+
 	if h.cfg.Timeout > 0 {
 		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(h.cfg.Timeout)*time.Second)
 		defer cancel()
 		req = req.WithContext(ctx)
 	}
 
-	resp, err := http_client.GetDefaultClient().Do(req)
+	client, err := h.client()
+	if err != nil {
+		h.logger.Errorw("cant create http client", "error", err.Error())
+		return err
+	}
+
+	resp, err := client.Do(req)
 	if resp != nil && resp.Body != nil {
 		defer resp.Body.Close()
 	}
@@ -51,9 +89,93 @@ func (h *httpNotifier) notify(record *singleRecord, input builder.Interfacable)
 		return err
 	}
 
+	if !h.isExpectedStatus(resp.StatusCode) {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		errStatus := fmt.Errorf("%w: %d %s", errUnexpectedStatus, resp.StatusCode, string(respBody))
+		h.logger.Errorw("cant inform about results request", "error", errStatus.Error())
+		return errStatus
+	}
+
+	return nil
+}
+
+// body return formatted body from config, result or json of the record
+func (h *httpNotifier) body(record *singleRecord, input builder.Interfacable) ([]byte, error) {
+	if h.cfg.Body != "" {
+		return []byte(formatWithRecord(h.cfg.Body, record, input)), nil
+	}
+
+	if h.cfg.OnlyMsg {
+		msg, err := recordMessage(record, true, false)
+		if err != nil {
+			return nil, err
+		}
+		return []byte(msg), nil
+	}
+
+	return json.Marshal(record)
+}
+
+// sign add HMAC-SHA256 of the body into header, "timestamp.body" is signed if timestamp header is provided
+func (h *httpNotifier) sign(req *http.Request, body []byte, record *singleRecord, input builder.Interfacable, now time.Time) error {
+	payload := body
+	if h.cfg.Signature.TimestampHeader != "" {
+		timestamp := strconv.FormatInt(now.Unix(), 10)
+		req.Header.Set(h.cfg.Signature.TimestampHeader, timestamp)
+		payload = append([]byte(timestamp+"."), body...)
+	}
+
+	mac := hmac.New(sha256.New, []byte(formatWithRecord(h.cfg.Signature.Secret, record, input)))
+	mac.Write(payload)
+	sum := mac.Sum(nil)
+
+	var signature string
+	switch h.cfg.Signature.Encoding {
+	case "", config.HexSignature:
+		signature = hex.EncodeToString(sum)
+	case config.Base64Signature:
+		signature = base64.StdEncoding.EncodeToString(sum)
+	default:
+		return fmt.Errorf("%w: %s", errUnknownSignatureEncoding, h.cfg.Signature.Encoding)
+	}
+
+	header := defaultSignatureHeader
+	if h.cfg.Signature.Header != "" {
+		header = h.cfg.Signature.Header
+	}
+	req.Header.Set(header, h.cfg.Signature.Prefix+signature)
 	return nil
 }
 
+func (h *httpNotifier) isExpectedStatus(status int) bool {
+	if len(h.cfg.ExpectedStatus) == 0 {
+		return status >= http.StatusOK && status < http.StatusMultipleChoices
+	}
+
+	for _, expected := range h.cfg.ExpectedStatus {
+		if status == expected {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *httpNotifier) client() (*http.Client, error) {
+	if h.cfg.TLS == nil {
+		return http_client.GetDefaultClient(), nil
+	}
+
+	transport, err := http_client.GetTransport(nil, h.cfg.TLS, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &http.Client{
+		Timeout:   defaultHttpTimeout,
+		Transport: transport,
+	}, nil
+}
+
 func (o *httpNotifier) GetLogger() logger.Logger {
 	return o.logger
 }
@@ -0,0 +1,149 @@
+package notifier_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"github.com/PxyUp/fitter/pkg/config"
+	"github.com/PxyUp/fitter/pkg/logger"
+	"github.com/PxyUp/fitter/pkg/notifier"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"log"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"testing"
+	"time"
+)
+
+func TestHttpNotifierSignature(t *testing.T) {
+	var body []byte
+	var headers http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var err error
+		body, err = io.ReadAll(r.Body)
+		require.NoError(t, err)
+		headers = r.Header
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	httpNotifier := notifier.NewHttpNotifier("news", &config.HttpConfig{
+		Url:            server.URL,
+		Method:         http.MethodPost,
+		Body:           `{"title": "{{{title}}}"}`,
+		ExpectedStatus: []int{http.StatusAccepted},
+		Signature: &config.HttpSignatureConfig{
+			Secret:          "secret",
+			Header:          "X-Hub-Signature-256",
+			Prefix:          "sha256=",
+			TimestampHeader: "X-Timestamp",
+		},
+	})
+	require.NoError(t, notifier.Inform(httpNotifier, "news", result(`{"title": "Go 2"}`), nil, false, logger.Null, nil))
+
+	assert.Equal(t, `{"title": "Go 2"}`, string(body))
+	assert.Equal(t, "application/json", headers.Get("Content-Type"))
+
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte(headers.Get("X-Timestamp") + "." + string(body)))
+	assert.Equal(t, "sha256="+hex.EncodeToString(mac.Sum(nil)), headers.Get("X-Hub-Signature-256"))
+
+	templateNotifier, err := notifier.WithTemplate(notifier.NewHttpNotifier("news", &config.HttpConfig{
+		Url:     server.URL,
+		Method:  http.MethodPost,
+		OnlyMsg: true,
+	}), "news", `{"items": {{json .Result}}}`, config.TextTemplate)
+	require.NoError(t, err)
+	require.NoError(t, notifier.Inform(templateNotifier, "news", result(`[{"title": "Go 2"}]`), nil, false, logger.Null, nil))
+	assert.JSONEq(t, `{"items": [{"title": "Go 2"}]}`, string(body))
+
+	strictNotifier := notifier.NewHttpNotifier("news", &config.HttpConfig{
+		Url:            server.URL,
+		Method:         http.MethodPost,
+		ExpectedStatus: []int{http.StatusOK},
+	})
+	assert.Error(t, notifier.Inform(strictNotifier, "news", result(`{"title": "Go 2"}`), nil, false, logger.Null, nil))
+}
+
+func TestHttpNotifierClientCertificate(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := path.Join(dir, "client.pem"), path.Join(dir, "client.key")
+	clientCert := writeClientCertificate(t, certFile, keyFile)
+
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(clientCert)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.Config.ErrorLog = log.New(io.Discard, "", 0)
+	server.TLS = &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  clientCAs,
+	}
+	server.StartTLS()
+	defer server.Close()
+
+	caFile := path.Join(dir, "ca.pem")
+	require.NoError(t, os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0600))
+
+	withoutCert := notifier.NewHttpNotifier("news", &config.HttpConfig{
+		Url:    server.URL,
+		Method: http.MethodPost,
+		TLS: &config.TLSConfig{
+			CAFile: caFile,
+		},
+	})
+	assert.Error(t, notifier.Inform(withoutCert, "news", result(`"hello"`), nil, false, logger.Null, nil))
+
+	withCert := notifier.NewHttpNotifier("news", &config.HttpConfig{
+		Url:    server.URL,
+		Method: http.MethodPost,
+		TLS: &config.TLSConfig{
+			CAFile:   caFile,
+			CertFile: certFile,
+			KeyFile:  keyFile,
+		},
+	})
+	assert.NoError(t, notifier.Inform(withCert, "news", result(`"hello"`), nil, false, logger.Null, nil))
+}
+
+func writeClientCertificate(t *testing.T, certFile string, keyFile string) *x509.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "fitter"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600))
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert
+}
@@ -0,0 +1,114 @@
+package notifier
+
+import (
+	"context"
+	"github.com/PxyUp/fitter/pkg/builder"
+	"github.com/PxyUp/fitter/pkg/config"
+	"github.com/PxyUp/fitter/pkg/logger"
+	"github.com/PxyUp/fitter/pkg/utils"
+	"github.com/segmentio/kafka-go"
+	"strings"
+	"sync"
+)
+
+var (
+	_ Notifier      = &kafkaNotifier{}
+	_ batchNotifier = &kafkaNotifier{}
+
+	kafkaWriters     = make(map[string]*kafka.Writer)
+	kafkaWriterMutex sync.Mutex
+)
+
+type kafkaNotifier struct {
+	logger logger.Logger
+	name   string
+	cfg    *config.KafkaNotifierConfig
+}
+
+func NewKafka(name string, cfg *config.KafkaNotifierConfig) *kafkaNotifier {
+	return &kafkaNotifier{
+		logger: logger.Null,
+		name:   name,
+		cfg:    cfg,
+	}
+}
+
+func (k *kafkaNotifier) WithLogger(logger logger.Logger) *kafkaNotifier {
+	k.logger = logger
+	return k
+}
+
+func (k *kafkaNotifier) GetLogger() logger.Logger {
+	return k.logger
+}
+
+// getKafkaWriter return shared writer for brokers, topic is set per message
+func getKafkaWriter(brokers []string) *kafka.Writer {
+	key := strings.Join(brokers, ",")
+
+	kafkaWriterMutex.Lock()
+	defer kafkaWriterMutex.Unlock()
+
+	if writer, ok := kafkaWriters[key]; ok {
+		return writer
+	}
+
+	writer := &kafka.Writer{
+		Addr:         kafka.TCP(brokers...),
+		Balancer:     &kafka.Hash{},
+		RequiredAcks: kafka.RequireAll,
+	}
+	kafkaWriters[key] = writer
+	return writer
+}
+
+func (k *kafkaNotifier) notify(record *singleRecord, input builder.Interfacable) error {
+	return k.notifyBatch([]*singleRecord{record}, input)
+}
+
+func (k *kafkaNotifier) notifyBatch(records []*singleRecord, input builder.Interfacable) error {
+	brokers := make([]string, len(k.cfg.Brokers))
+	for i, broker := range k.cfg.Brokers {
+		brokers[i] = utils.Format(broker, nil, nil, input)
+	}
+	writer := getKafkaWriter(brokers)
+
+	for _, batch := range splitBatches(records, k.cfg.BatchSize) {
+		messages, err := toQueueMessages(batch, k.cfg.KeyPath)
+		if err != nil {
+			k.logger.Errorw("cant marshal message", "error", err.Error())
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), queueTimeout)
+		err = writer.WriteMessages(ctx, k.messages(messages, input)...)
+		cancel()
+		if err != nil {
+			k.logger.Errorw("cant write messages to kafka", "error", err.Error())
+			return err
+		}
+	}
+
+	return nil
+}
+
+// messages build kafka messages with formatted topic and headers
+func (k *kafkaNotifier) messages(messages []*queueMessage, input builder.Interfacable) []kafka.Message {
+	kafkaMessages := make([]kafka.Message, len(messages))
+	for i, msg := range messages {
+		headers := []kafka.Header{{Key: "name", Value: []byte(k.name)}}
+		for hk, hv := range k.cfg.Headers {
+			headers = append(headers, kafka.Header{Key: hk, Value: []byte(formatWithRecord(hv, msg.record, input))})
+		}
+
+		kafkaMessages[i] = kafka.Message{
+			Topic:   formatWithRecord(k.cfg.Topic, msg.record, input),
+			Value:   msg.body,
+			Headers: headers,
+		}
+		if msg.key != "" {
+			kafkaMessages[i].Key = []byte(msg.key)
+		}
+	}
+	return kafkaMessages
+}
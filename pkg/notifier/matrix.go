@@ -0,0 +1,101 @@
+package notifier
+
+import (
+	"fmt"
+	"github.com/PxyUp/fitter/pkg/builder"
+	"github.com/PxyUp/fitter/pkg/config"
+	"github.com/PxyUp/fitter/pkg/logger"
+	"github.com/PxyUp/fitter/pkg/utils"
+	"go.uber.org/atomic"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// matrixContentLimit keep event below 65536 bytes limit, rest is reserved for other fields of the event
+	matrixContentLimit = 64000
+	defaultMsgType     = "m.text"
+)
+
+var (
+	_ Notifier = &matrixNotifier{}
+
+	matrixTxnCounter = atomic.NewUint64(0)
+)
+
+type matrixNotifier struct {
+	logger logger.Logger
+	name   string
+	cfg    *config.MatrixNotifierConfig
+}
+
+type matrixMessage struct {
+	MsgType       string `json:"msgtype"`
+	Body          string `json:"body"`
+	Format        string `json:"format,omitempty"`
+	FormattedBody string `json:"formatted_body,omitempty"`
+}
+
+func NewMatrix(name string, cfg *config.MatrixNotifierConfig) *matrixNotifier {
+	return &matrixNotifier{
+		logger: logger.Null,
+		name:   name,
+		cfg:    cfg,
+	}
+}
+
+func (m *matrixNotifier) WithLogger(logger logger.Logger) *matrixNotifier {
+	m.logger = logger
+	return m
+}
+
+func (m *matrixNotifier) GetLogger() logger.Logger {
+	return m.logger
+}
+
+func (m *matrixNotifier) notify(record *singleRecord, input builder.Interfacable) error {
+	msg, err := recordMessage(record, m.cfg.OnlyMsg, m.cfg.Pretty)
+	if err != nil {
+		m.logger.Errorw("cant create message", "error", err.Error())
+		return err
+	}
+
+	msgType := defaultMsgType
+	if m.cfg.MsgType != "" {
+		msgType = m.cfg.MsgType
+	}
+
+	headers := map[string]string{
+		"Authorization": "Bearer " + utils.Format(m.cfg.AccessToken, nil, nil, input),
+	}
+	roomUrl := strings.TrimSuffix(utils.Format(m.cfg.Homeserver, nil, nil, input), "/") +
+		"/_matrix/client/v3/rooms/" + url.PathEscape(formatWithRecord(m.cfg.RoomId, record, input)) + "/send/m.room.message/"
+
+	// formatted body can not be split without breaking html, it is sent with first part only and takes at most half of the event
+	formattedBody := ""
+	if m.cfg.FormattedBody != "" {
+		formattedBody = sizedMessageParts(formatWithRecord(m.cfg.FormattedBody, record, input), matrixContentLimit/2, true, jsonSize)[0]
+	}
+
+	// limits are measured in bytes of the json encoded event
+	for i, part := range sizedMessageParts(msg, matrixContentLimit-jsonSize(formattedBody), m.cfg.Truncate, jsonSize) {
+		event := &matrixMessage{
+			MsgType: msgType,
+			Body:    part,
+		}
+		if i == 0 && formattedBody != "" {
+			event.Format = "org.matrix.custom.html"
+			event.FormattedBody = formattedBody
+		}
+
+		txnId := fmt.Sprintf("fitter.%d.%d", time.Now().UnixNano(), matrixTxnCounter.Inc())
+		if err = sendJSON(http.MethodPut, roomUrl+txnId, headers, event, m.cfg.Timeout); err != nil {
+			m.logger.Errorw("unable to send matrix message", "error", err.Error())
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,189 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/PxyUp/fitter/pkg/http_client"
+	"github.com/tidwall/gjson"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	defaultMessageTimeout = 30 * time.Second
+	truncateSuffix        = "…"
+)
+
+var (
+	errUnexpectedStatus = errors.New("unexpected status code")
+	errInvalidJSON      = errors.New("invalid json after formatting")
+)
+
+// recordMessage return text of the record for chat and http notifiers, string results(for example from Template) are sent without quotes
+func recordMessage(record *singleRecord, onlyMsg bool, pretty bool) (string, error) {
+	if record.Error != nil {
+		if onlyMsg {
+			return (*record.Error).Error(), nil
+		}
+	} else if onlyMsg {
+		result := gjson.ParseBytes(record.Body)
+		if result.Type == gjson.String {
+			return result.String(), nil
+		}
+	}
+
+	msg := []byte(record.Body)
+	if !onlyMsg {
+		body, err := json.Marshal(record)
+		if err != nil {
+			return "", err
+		}
+		msg = body
+	}
+
+	if pretty {
+		var prettyJSON bytes.Buffer
+		if err := json.Indent(&prettyJSON, msg, "", " "); err == nil {
+			return prettyJSON.String(), nil
+		}
+	}
+
+	return string(msg), nil
+}
+
+// messageParts split message into parts with at most limit characters or truncate it
+func messageParts(msg string, limit int, truncate bool) []string {
+	return sizedMessageParts(msg, limit, truncate, utf8.RuneCountInString)
+}
+
+// sizedMessageParts split message into parts with size at most limit or truncate it, size of the message is sum of sizes of its characters
+func sizedMessageParts(msg string, limit int, truncate bool, size func(string) int) []string {
+	if size(msg) <= limit {
+		return []string{msg}
+	}
+
+	if truncate {
+		return []string{messagePrefix(msg, limit-size(truncateSuffix), size) + truncateSuffix}
+	}
+
+	return splitMessage(msg, limit, size)
+}
+
+// messagePrefix return longest prefix of the message with size at most limit
+func messagePrefix(msg string, limit int, size func(string) int) string {
+	total := 0
+	for i, r := range msg {
+		total += size(string(r))
+		if total > limit {
+			return msg[:i]
+		}
+	}
+	return msg
+}
+
+// splitMessage split message by lines, lines longer than limit are split by characters
+func splitMessage(msg string, limit int, size func(string) int) []string {
+	var parts []string
+	var current strings.Builder
+	currentLen := 0
+
+	flush := func() {
+		if currentLen > 0 {
+			parts = append(parts, current.String())
+			current.Reset()
+			currentLen = 0
+		}
+	}
+
+	for _, line := range strings.SplitAfter(msg, "\n") {
+		lineLen := size(line)
+		if currentLen+lineLen <= limit {
+			current.WriteString(line)
+			currentLen += lineLen
+			continue
+		}
+
+		flush()
+		for lineLen > limit {
+			part := messagePrefix(line, limit, size)
+			if part == "" {
+				_, runeSize := utf8.DecodeRuneInString(line)
+				part = line[:runeSize]
+			}
+			parts = append(parts, part)
+			line = line[len(part):]
+			lineLen = size(line)
+		}
+		current.WriteString(line)
+		currentLen = lineLen
+	}
+	flush()
+
+	return parts
+}
+
+// jsonSize return amount of bytes of the string inside json encoded by encoding/json, quotes are not counted
+func jsonSize(s string) int {
+	total := 0
+	for i, r := range s {
+		switch {
+		case r == '"' || r == '\\' || r == '\n' || r == '\r' || r == '\t':
+			total += 2
+		case r < 0x20 || r == '<' || r == '>' || r == '&' || r == '\u2028' || r == '\u2029':
+			total += 6
+		case r == utf8.RuneError:
+			_, runeSize := utf8.DecodeRuneInString(s[i:])
+			if runeSize == 1 {
+				total += 6
+			} else {
+				total += runeSize
+			}
+		default:
+			total += utf8.RuneLen(r)
+		}
+	}
+	return total
+}
+
+// sendJSON send json body and check status code of the response
+func sendJSON(method string, url string, headers map[string]string, body interface{}, timeout uint32) error {
+	bb, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	tt := defaultMessageTimeout
+	if timeout > 0 {
+		tt = time.Duration(timeout) * time.Second
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), tt)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(bb))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", jsonContentType)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := http_client.GetDefaultClient().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("%w: %d %s", errUnexpectedStatus, resp.StatusCode, string(respBody))
+	}
+
+	return nil
+}
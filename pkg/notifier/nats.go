@@ -0,0 +1,143 @@
+package notifier
+
+import (
+	"context"
+	"github.com/PxyUp/fitter/pkg/builder"
+	"github.com/PxyUp/fitter/pkg/config"
+	"github.com/PxyUp/fitter/pkg/logger"
+	"github.com/PxyUp/fitter/pkg/utils"
+	"github.com/nats-io/nats.go"
+	"sync"
+)
+
+const (
+	// natsKeyHeader is not Nats-Msg-Id, otherwise JetStream drop updates with same key inside duplicate window
+	natsKeyHeader = "Fitter-Key"
+)
+
+var (
+	_ Notifier      = &natsNotifier{}
+	_ batchNotifier = &natsNotifier{}
+
+	natsConns     = make(map[string]*nats.Conn)
+	natsConnMutex sync.Mutex
+)
+
+type natsNotifier struct {
+	logger logger.Logger
+	name   string
+	cfg    *config.NATSNotifierConfig
+}
+
+func NewNATS(name string, cfg *config.NATSNotifierConfig) *natsNotifier {
+	return &natsNotifier{
+		logger: logger.Null,
+		name:   name,
+		cfg:    cfg,
+	}
+}
+
+func (n *natsNotifier) WithLogger(logger logger.Logger) *natsNotifier {
+	n.logger = logger
+	return n
+}
+
+func (n *natsNotifier) GetLogger() logger.Logger {
+	return n.logger
+}
+
+// getNATSConn return shared connection, closed connections are replaced with new one
+func getNATSConn(url string) (*nats.Conn, error) {
+	natsConnMutex.Lock()
+	defer natsConnMutex.Unlock()
+
+	if conn, ok := natsConns[url]; ok && !conn.IsClosed() {
+		return conn, nil
+	}
+
+	conn, err := nats.Connect(url)
+	if err != nil {
+		return nil, err
+	}
+	natsConns[url] = conn
+	return conn, nil
+}
+
+func (n *natsNotifier) notify(record *singleRecord, input builder.Interfacable) error {
+	return n.notifyBatch([]*singleRecord{record}, input)
+}
+
+func (n *natsNotifier) notifyBatch(records []*singleRecord, input builder.Interfacable) error {
+	conn, err := getNATSConn(utils.Format(n.cfg.Url, nil, nil, input))
+	if err != nil {
+		n.logger.Errorw("cant connect to nats", "error", err.Error())
+		return err
+	}
+
+	for _, batch := range splitBatches(records, n.cfg.BatchSize) {
+		messages, errMessages := toQueueMessages(batch, n.cfg.KeyPath)
+		if errMessages != nil {
+			n.logger.Errorw("cant marshal message", "error", errMessages.Error())
+			return errMessages
+		}
+
+		if err = n.publish(conn, messages, input); err != nil {
+			n.logger.Errorw("cant publish messages", "error", err.Error())
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (n *natsNotifier) publish(conn *nats.Conn, messages []*queueMessage, input builder.Interfacable) error {
+	ctx, cancel := context.WithTimeout(context.Background(), queueTimeout)
+	defer cancel()
+
+	natsMessages := make([]*nats.Msg, len(messages))
+	for i, msg := range messages {
+		natsMsg := nats.NewMsg(formatWithRecord(n.cfg.Subject, msg.record, input))
+		natsMsg.Data = msg.body
+		for k, v := range n.cfg.Headers {
+			natsMsg.Header.Set(k, formatWithRecord(v, msg.record, input))
+		}
+		if msg.key != "" {
+			natsMsg.Header.Set(natsKeyHeader, msg.key)
+		}
+		natsMessages[i] = natsMsg
+	}
+
+	if !n.cfg.JetStream {
+		for _, natsMsg := range natsMessages {
+			if err := conn.PublishMsg(natsMsg); err != nil {
+				return err
+			}
+		}
+		return conn.FlushWithContext(ctx)
+	}
+
+	js, err := conn.JetStream()
+	if err != nil {
+		return err
+	}
+
+	futures := make([]nats.PubAckFuture, len(natsMessages))
+	for i, natsMsg := range natsMessages {
+		futures[i], err = js.PublishMsgAsync(natsMsg)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, future := range futures {
+		select {
+		case <-future.Ok():
+		case errAck := <-future.Err():
+			return errAck
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return nil
+}
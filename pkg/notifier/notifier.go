@@ -21,6 +21,11 @@ type Notifier interface {
 	GetLogger() logger.Logger
 }
 
+// batchNotifier notify about all items of the array at once
+type batchNotifier interface {
+	notifyBatch([]*singleRecord, builder.Interfacable) error
+}
+
 func recordToInterfacable(record *singleRecord) builder.Interfacable {
 	if record.Error != nil {
 		return builder.String((*record.Error).Error())
@@ -107,6 +112,10 @@ func Inform(notifier Notifier, name string, result *parser.ParseResult, errResul
 		return err
 	}
 
+	if batch, ok := notifier.(batchNotifier); ok {
+		return batch.notifyBatch(records, input)
+	}
+
 	for _, rec := range records {
 		errNotify := notifier.notify(rec, input)
 		if errNotify != nil {
@@ -0,0 +1,59 @@
+package notifier
+
+import (
+	"encoding/json"
+	"github.com/tidwall/gjson"
+	"time"
+)
+
+const (
+	defaultBatchSize = 100
+	queueTimeout     = 30 * time.Second
+)
+
+// queueMessage is message for queue sinks, body is json of the record
+type queueMessage struct {
+	record *singleRecord
+	body   []byte
+	key    string
+}
+
+// splitBatches split records into batches with batchSize or 100 records
+func splitBatches(records []*singleRecord, batchSize uint32) [][]*singleRecord {
+	size := defaultBatchSize
+	if batchSize > 0 {
+		size = int(batchSize)
+	}
+
+	var batches [][]*singleRecord
+	for start := 0; start < len(records); start += size {
+		end := start + size
+		if end > len(records) {
+			end = len(records)
+		}
+		batches = append(batches, records[start:end])
+	}
+	return batches
+}
+
+func toQueueMessages(records []*singleRecord, keyPath string) ([]*queueMessage, error) {
+	messages := make([]*queueMessage, len(records))
+	for i, record := range records {
+		body, err := json.Marshal(record)
+		if err != nil {
+			return nil, err
+		}
+
+		key := ""
+		if keyPath != "" && record.Error == nil {
+			key = gjson.GetBytes(record.Body, keyPath).String()
+		}
+
+		messages[i] = &queueMessage{
+			record: record,
+			body:   body,
+			key:    key,
+		}
+	}
+	return messages, nil
+}
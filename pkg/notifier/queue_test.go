@@ -0,0 +1,141 @@
+package notifier_test
+
+import (
+	"github.com/PxyUp/fitter/pkg/config"
+	"github.com/PxyUp/fitter/pkg/logger"
+	"github.com/PxyUp/fitter/pkg/notifier"
+	natsserver "github.com/nats-io/nats-server/v2/test"
+	"github.com/nats-io/nats.go"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+const products = `[
+	{"sku": "A-1", "category": "fruit"},
+	{"sku": "B-2", "category": "milk"}
+]`
+
+func TestNATSNotifier(t *testing.T) {
+	server := natsserver.RunRandClientPortServer()
+	defer server.Shutdown()
+
+	conn, err := nats.Connect(server.ClientURL())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	subscription, err := conn.SubscribeSync("products.>")
+	require.NoError(t, err)
+	require.NoError(t, conn.Flush())
+
+	require.NoError(t, notifier.Inform(notifier.NewNATS("products", &config.NATSNotifierConfig{
+		Url:     server.ClientURL(),
+		Subject: "products.{{{category}}}",
+		KeyPath: "sku",
+		Headers: map[string]string{
+			"sku": "{{{sku}}}",
+		},
+	}), "products", result(products), nil, true, logger.Null, nil))
+
+	msg, err := subscription.NextMsg(time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, "products.fruit", msg.Subject)
+	assert.Equal(t, "A-1", msg.Header.Get("Fitter-Key"))
+	assert.Empty(t, msg.Header.Get(nats.MsgIdHdr))
+	assert.Equal(t, "A-1", msg.Header.Get("sku"))
+	assert.JSONEq(t, `{"name": "products", "body": {"sku": "A-1", "category": "fruit"}, "index": 0}`, string(msg.Data))
+
+	msg, err = subscription.NextMsg(time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, "products.milk", msg.Subject)
+	assert.Equal(t, "B-2", msg.Header.Get("sku"))
+}
+
+func TestNATSNotifier_JetStream(t *testing.T) {
+	opts := natsserver.DefaultTestOptions
+	opts.Port = -1
+	opts.JetStream = true
+	opts.StoreDir = t.TempDir()
+	server := natsserver.RunServer(&opts)
+	defer server.Shutdown()
+
+	conn, err := nats.Connect(server.ClientURL())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	js, err := conn.JetStream()
+	require.NoError(t, err)
+	_, err = js.AddStream(&nats.StreamConfig{
+		Name:     "PRODUCTS",
+		Subjects: []string{"products.>"},
+	})
+	require.NoError(t, err)
+
+	natsNotifier := notifier.NewNATS("products", &config.NATSNotifierConfig{
+		Url:       server.ClientURL(),
+		Subject:   "products.{{{category}}}",
+		JetStream: true,
+		KeyPath:   "sku",
+	})
+	require.NoError(t, notifier.Inform(natsNotifier, "products", result(products), nil, true, logger.Null, nil))
+	// updates with same keys are not deduplicated by JetStream
+	require.NoError(t, notifier.Inform(natsNotifier, "products", result(products), nil, true, logger.Null, nil))
+
+	info, err := js.StreamInfo("PRODUCTS")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4), info.State.Msgs)
+
+	assert.Error(t, notifier.Inform(notifier.NewNATS("products", &config.NATSNotifierConfig{
+		Url:       server.ClientURL(),
+		Subject:   "unknown",
+		JetStream: true,
+	}), "products", result(products), nil, true, logger.Null, nil))
+}
+
+func TestKafkaMessages(t *testing.T) {
+	messages, err := notifier.KafkaMessages(notifier.NewKafka("products", &config.KafkaNotifierConfig{
+		Topic:   "products-{{{category}}}",
+		KeyPath: "sku",
+		Headers: map[string]string{
+			"position": "{HUMAN_INDEX}",
+		},
+	}), []string{`{"sku": "A-1", "category": "fruit"}`, `{"category": "milk"}`}, nil)
+	require.NoError(t, err)
+	require.Len(t, messages, 2)
+
+	assert.Equal(t, "products-fruit", messages[0].Topic)
+	assert.Equal(t, "A-1", string(messages[0].Key))
+	require.Len(t, messages[0].Headers, 2)
+	assert.Equal(t, "name", messages[0].Headers[0].Key)
+	assert.Equal(t, "products", string(messages[0].Headers[0].Value))
+	assert.Equal(t, "position", messages[0].Headers[1].Key)
+	assert.Equal(t, "1", string(messages[0].Headers[1].Value))
+	assert.JSONEq(t, `{"name": "products", "body": {"sku": "A-1", "category": "fruit"}, "index": 0}`, string(messages[0].Value))
+
+	assert.Equal(t, "products-milk", messages[1].Topic)
+	assert.Nil(t, messages[1].Key)
+	assert.Equal(t, "2", string(messages[1].Headers[1].Value))
+}
+
+func TestAMQPPublishings(t *testing.T) {
+	routingKeys, publishings, err := notifier.AMQPPublishings(notifier.NewAMQP("products", &config.AMQPNotifierConfig{
+		Exchange:   "products",
+		RoutingKey: "products.{{{category}}}",
+		KeyPath:    "sku",
+		Headers: map[string]string{
+			"sku": "{{{sku}}}",
+		},
+	}), []string{`{"sku": "A-1", "category": "fruit"}`, `{"sku": "B-2", "category": "milk"}`}, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"products.fruit", "products.milk"}, routingKeys)
+	require.Len(t, publishings, 2)
+	assert.Equal(t, "application/json", publishings[0].ContentType)
+	assert.Equal(t, amqp.Persistent, publishings[0].DeliveryMode)
+	assert.Equal(t, "A-1", publishings[0].MessageId)
+	assert.Equal(t, amqp.Table{"name": "products", "sku": "A-1"}, publishings[0].Headers)
+	assert.JSONEq(t, `{"name": "products", "body": {"sku": "A-1", "category": "fruit"}, "index": 0}`, string(publishings[0].Body))
+	assert.Equal(t, "B-2", publishings[1].MessageId)
+}
@@ -6,7 +6,6 @@ import (
 	"github.com/PxyUp/fitter/pkg/builder"
 	"github.com/PxyUp/fitter/pkg/config"
 	"github.com/PxyUp/fitter/pkg/logger"
-	"github.com/redis/go-redis/v9"
 	"time"
 )
 
@@ -21,11 +20,7 @@ type redisNotifier struct {
 }
 
 func (r *redisNotifier) notify(record *singleRecord, input builder.Interfacable) error {
-	redisClient := redis.NewClient(&redis.Options{
-		Addr:     formatWithRecord(r.cfg.Addr, record, input),
-		Password: formatWithRecord(r.cfg.Password, record, input),
-		DB:       r.cfg.DB,
-	})
+	redisClient := getRedisClient(formatWithRecord(r.cfg.Addr, record, input), formatWithRecord(r.cfg.Password, record, input), r.cfg.DB)
 
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
 	defer cancel()
@@ -42,7 +37,7 @@ func (r *redisNotifier) notify(record *singleRecord, input builder.Interfacable)
 		return errSend
 	}
 
-	return redisClient.Close()
+	return nil
 }
 
 func (o *redisNotifier) GetLogger() logger.Logger {
@@ -0,0 +1,32 @@
+package notifier
+
+import (
+	"fmt"
+	"github.com/redis/go-redis/v9"
+	"sync"
+)
+
+var (
+	redisClients     = make(map[string]*redis.Client)
+	redisClientMutex sync.Mutex
+)
+
+// getRedisClient return shared client for address and database, client keep pool of connections
+func getRedisClient(addr string, password string, db int) *redis.Client {
+	key := fmt.Sprintf("%s|%s|%d", addr, password, db)
+
+	redisClientMutex.Lock()
+	defer redisClientMutex.Unlock()
+
+	if client, ok := redisClients[key]; ok {
+		return client
+	}
+
+	client := redis.NewClient(&redis.Options{
+		Addr:     addr,
+		Password: password,
+		DB:       db,
+	})
+	redisClients[key] = client
+	return client
+}
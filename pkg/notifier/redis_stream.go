@@ -0,0 +1,82 @@
+package notifier
+
+import (
+	"context"
+	"github.com/PxyUp/fitter/pkg/builder"
+	"github.com/PxyUp/fitter/pkg/config"
+	"github.com/PxyUp/fitter/pkg/logger"
+	"github.com/PxyUp/fitter/pkg/utils"
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	_ Notifier      = &redisStreamNotifier{}
+	_ batchNotifier = &redisStreamNotifier{}
+)
+
+type redisStreamNotifier struct {
+	logger logger.Logger
+	name   string
+	cfg    *config.RedisStreamNotifierConfig
+}
+
+func NewRedisStream(name string, cfg *config.RedisStreamNotifierConfig) *redisStreamNotifier {
+	return &redisStreamNotifier{
+		logger: logger.Null,
+		name:   name,
+		cfg:    cfg,
+	}
+}
+
+func (r *redisStreamNotifier) WithLogger(logger logger.Logger) *redisStreamNotifier {
+	r.logger = logger
+	return r
+}
+
+func (r *redisStreamNotifier) GetLogger() logger.Logger {
+	return r.logger
+}
+
+func (r *redisStreamNotifier) notify(record *singleRecord, input builder.Interfacable) error {
+	return r.notifyBatch([]*singleRecord{record}, input)
+}
+
+func (r *redisStreamNotifier) notifyBatch(records []*singleRecord, input builder.Interfacable) error {
+	client := getRedisClient(utils.Format(r.cfg.Addr, nil, nil, input), utils.Format(r.cfg.Password, nil, nil, input), r.cfg.DB)
+
+	for _, batch := range splitBatches(records, r.cfg.BatchSize) {
+		messages, err := toQueueMessages(batch, r.cfg.KeyPath)
+		if err != nil {
+			r.logger.Errorw("cant marshal message", "error", err.Error())
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), queueTimeout)
+		_, err = client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			for _, msg := range messages {
+				values := []interface{}{"name", r.name, "body", string(msg.body)}
+				if msg.key != "" {
+					values = append(values, "key", msg.key)
+				}
+				for k, v := range r.cfg.Headers {
+					values = append(values, k, formatWithRecord(v, msg.record, input))
+				}
+
+				pipe.XAdd(ctx, &redis.XAddArgs{
+					Stream: formatWithRecord(r.cfg.Stream, msg.record, input),
+					MaxLen: r.cfg.MaxLen,
+					Approx: r.cfg.MaxLen > 0,
+					Values: values,
+				})
+			}
+			return nil
+		})
+		cancel()
+		if err != nil {
+			r.logger.Errorw("cant add messages to stream", "error", err.Error())
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,45 @@
+package notifier_test
+
+import (
+	"context"
+	"github.com/PxyUp/fitter/pkg/config"
+	"github.com/PxyUp/fitter/pkg/logger"
+	"github.com/PxyUp/fitter/pkg/notifier"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestRedisStreamNotifier(t *testing.T) {
+	server := miniredis.RunT(t)
+
+	streamNotifier := notifier.NewRedisStream("products", &config.RedisStreamNotifierConfig{
+		Addr:    server.Addr(),
+		Stream:  "products",
+		KeyPath: "sku",
+		Headers: map[string]string{
+			"source": "fitter",
+		},
+		BatchSize: 1,
+	})
+
+	require.NoError(t, notifier.Inform(streamNotifier, "products", result(`[
+		{"sku": "A-1", "price": 10},
+		{"sku": "B-2", "price": 20}
+	]`), nil, true, logger.Null, nil))
+
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	defer client.Close()
+
+	entries, err := client.XRange(context.Background(), "products", "-", "+").Result()
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	assert.Equal(t, "A-1", entries[0].Values["key"])
+	assert.Equal(t, "products", entries[0].Values["name"])
+	assert.Equal(t, "fitter", entries[0].Values["source"])
+	assert.JSONEq(t, `{"name": "products", "body": {"sku": "A-1", "price": 10}, "index": 0}`, entries[0].Values["body"].(string))
+	assert.Equal(t, "B-2", entries[1].Values["key"])
+}
@@ -0,0 +1,91 @@
+package notifier
+
+import (
+	"encoding/json"
+	"github.com/PxyUp/fitter/pkg/builder"
+	"github.com/PxyUp/fitter/pkg/config"
+	"github.com/PxyUp/fitter/pkg/logger"
+	"net/http"
+)
+
+const (
+	slackTextLimit = 40000
+)
+
+var (
+	_ Notifier = &slackNotifier{}
+)
+
+type slackNotifier struct {
+	logger logger.Logger
+	name   string
+	cfg    *config.SlackNotifierConfig
+}
+
+type slackMessage struct {
+	Text      string          `json:"text"`
+	Blocks    json.RawMessage `json:"blocks,omitempty"`
+	Channel   string          `json:"channel,omitempty"`
+	Username  string          `json:"username,omitempty"`
+	IconEmoji string          `json:"icon_emoji,omitempty"`
+}
+
+func NewSlack(name string, cfg *config.SlackNotifierConfig) *slackNotifier {
+	return &slackNotifier{
+		logger: logger.Null,
+		name:   name,
+		cfg:    cfg,
+	}
+}
+
+func (s *slackNotifier) WithLogger(logger logger.Logger) *slackNotifier {
+	s.logger = logger
+	return s
+}
+
+func (s *slackNotifier) GetLogger() logger.Logger {
+	return s.logger
+}
+
+func (s *slackNotifier) notify(record *singleRecord, input builder.Interfacable) error {
+	msg, err := recordMessage(record, s.cfg.OnlyMsg, s.cfg.Pretty)
+	if err != nil {
+		s.logger.Errorw("cant create message", "error", err.Error())
+		return err
+	}
+
+	url := formatWithRecord(s.cfg.WebhookUrl, record, input)
+
+	// blocks are sent once, text is used as fallback for notifications
+	if len(s.cfg.Blocks) > 0 {
+		blocks := json.RawMessage(formatWithRecord(string(s.cfg.Blocks), record, input))
+		if !json.Valid(blocks) {
+			s.logger.Errorw("invalid slack blocks", "blocks", string(blocks))
+			return errInvalidJSON
+		}
+		return s.send(url, messageParts(msg, slackTextLimit, true)[0], blocks)
+	}
+
+	for _, part := range messageParts(msg, slackTextLimit, s.cfg.Truncate) {
+		if err = s.send(url, part, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *slackNotifier) send(url string, text string, blocks json.RawMessage) error {
+	err := sendJSON(http.MethodPost, url, nil, &slackMessage{
+		Text:      text,
+		Blocks:    blocks,
+		Channel:   s.cfg.Channel,
+		Username:  s.cfg.Username,
+		IconEmoji: s.cfg.IconEmoji,
+	}, s.cfg.Timeout)
+	if err != nil {
+		s.logger.Errorw("unable to send slack message", "error", err.Error())
+		return err
+	}
+	return nil
+}
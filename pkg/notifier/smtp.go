@@ -0,0 +1,258 @@
+package notifier
+
+import (
+	"bytes"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"github.com/PxyUp/fitter/pkg/builder"
+	"github.com/PxyUp/fitter/pkg/config"
+	"github.com/PxyUp/fitter/pkg/logger"
+	"github.com/PxyUp/fitter/pkg/utils"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultSMTPPort    = 587
+	defaultSMTPTLSPort = 465
+)
+
+var (
+	_ Notifier = &smtpNotifier{}
+
+	errSMTPHeader = errors.New("email address contains CR or LF")
+)
+
+type smtpNotifier struct {
+	logger logger.Logger
+	name   string
+	cfg    *config.SMTPNotifierConfig
+}
+
+func NewSMTP(name string, cfg *config.SMTPNotifierConfig) *smtpNotifier {
+	return &smtpNotifier{
+		logger: logger.Null,
+		name:   name,
+		cfg:    cfg,
+	}
+}
+
+func (s *smtpNotifier) WithLogger(logger logger.Logger) *smtpNotifier {
+	s.logger = logger
+	return s
+}
+
+func (s *smtpNotifier) GetLogger() logger.Logger {
+	return s.logger
+}
+
+func (s *smtpNotifier) notify(record *singleRecord, input builder.Interfacable) error {
+	text := ""
+	if s.cfg.Text != "" {
+		text = formatWithRecord(s.cfg.Text, record, input)
+	} else if s.cfg.HTML == "" {
+		msg, err := recordMessage(record, s.cfg.OnlyMsg, s.cfg.Pretty)
+		if err != nil {
+			s.logger.Errorw("cant create message", "error", err.Error())
+			return err
+		}
+		text = msg
+	}
+
+	html := ""
+	if s.cfg.HTML != "" {
+		html = formatWithRecord(s.cfg.HTML, record, input)
+	}
+
+	to := make([]string, len(s.cfg.To))
+	for i, recipient := range s.cfg.To {
+		to[i] = formatWithRecord(recipient, record, input)
+	}
+
+	email, err := s.buildEmail(to, formatWithRecord(s.cfg.Subject, record, input), text, html)
+	if err != nil {
+		s.logger.Errorw("cant create email", "error", err.Error())
+		return err
+	}
+
+	if err = s.send(to, email, input); err != nil {
+		s.logger.Errorw("unable to send email", "error", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// headerAddresses format addresses for email header, value with CR or LF is rejected, so record data can not add headers
+func headerAddresses(addresses ...string) (string, error) {
+	formatted := make([]string, len(addresses))
+	for i, address := range addresses {
+		if strings.ContainsAny(address, "\r\n") {
+			return "", errSMTPHeader
+		}
+
+		parsed, err := mail.ParseAddress(address)
+		if err != nil {
+			return "", err
+		}
+		formatted[i] = parsed.String()
+	}
+
+	return strings.Join(formatted, ", "), nil
+}
+
+func (s *smtpNotifier) buildEmail(to []string, subject string, text string, html string) ([]byte, error) {
+	from, err := headerAddresses(s.cfg.From)
+	if err != nil {
+		return nil, err
+	}
+	recipients, err := headerAddresses(to...)
+	if err != nil {
+		return nil, err
+	}
+
+	var email bytes.Buffer
+	email.WriteString("From: " + from + "\r\n")
+	email.WriteString("To: " + recipients + "\r\n")
+	email.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", subject) + "\r\n")
+	email.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	email.WriteString("MIME-Version: 1.0\r\n")
+
+	if text == "" || html == "" {
+		contentType := "text/plain; charset=utf-8"
+		body := text
+		if html != "" {
+			contentType = "text/html; charset=utf-8"
+			body = html
+		}
+		email.WriteString("Content-Type: " + contentType + "\r\n")
+		email.WriteString("Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+		if err := writeQuotedPrintable(&email, body); err != nil {
+			return nil, err
+		}
+		return email.Bytes(), nil
+	}
+
+	var parts bytes.Buffer
+	writer := multipart.NewWriter(&parts)
+	for _, part := range []struct {
+		contentType string
+		body        string
+	}{
+		{contentType: "text/plain; charset=utf-8", body: text},
+		{contentType: "text/html; charset=utf-8", body: html},
+	} {
+		header := make(textproto.MIMEHeader)
+		header.Set("Content-Type", part.contentType)
+		header.Set("Content-Transfer-Encoding", "quoted-printable")
+		partWriter, err := writer.CreatePart(header)
+		if err != nil {
+			return nil, err
+		}
+		if err = writeQuotedPrintable(partWriter, part.body); err != nil {
+			return nil, err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	email.WriteString("Content-Type: multipart/alternative; boundary=" + writer.Boundary() + "\r\n\r\n")
+	email.Write(parts.Bytes())
+	return email.Bytes(), nil
+}
+
+func writeQuotedPrintable(w interface{ Write([]byte) (int, error) }, body string) error {
+	qp := quotedprintable.NewWriter(w)
+	if _, err := qp.Write([]byte(body)); err != nil {
+		return err
+	}
+	return qp.Close()
+}
+
+func (s *smtpNotifier) send(to []string, email []byte, input builder.Interfacable) error {
+	host := utils.Format(s.cfg.Host, nil, nil, input)
+	port := s.cfg.Port
+	if port == 0 {
+		port = defaultSMTPPort
+		if s.cfg.TLS {
+			port = defaultSMTPTLSPort
+		}
+	}
+
+	conn, err := net.DialTimeout("tcp", net.JoinHostPort(host, strconv.Itoa(port)), defaultMessageTimeout)
+	if err != nil {
+		return err
+	}
+	if err = conn.SetDeadline(time.Now().Add(defaultMessageTimeout)); err != nil {
+		conn.Close()
+		return err
+	}
+
+	tlsConfig := &tls.Config{ServerName: host}
+	if s.cfg.TLS {
+		conn = tls.Client(conn, tlsConfig)
+	}
+
+	client, err := smtp.NewClient(conn, host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if !s.cfg.TLS {
+		if ok, _ := client.Extension("STARTTLS"); ok {
+			if err = client.StartTLS(tlsConfig); err != nil {
+				return err
+			}
+		}
+	}
+
+	if s.cfg.Username != "" {
+		auth := smtp.PlainAuth("", utils.Format(s.cfg.Username, nil, nil, input), utils.Format(s.cfg.Password, nil, nil, input), host)
+		if err = client.Auth(auth); err != nil {
+			return err
+		}
+	}
+
+	if err = client.Mail(envelopeAddress(s.cfg.From)); err != nil {
+		return err
+	}
+	for _, recipient := range to {
+		if err = client.Rcpt(envelopeAddress(recipient)); err != nil {
+			return fmt.Errorf("recipient %s: %w", recipient, err)
+		}
+	}
+
+	writer, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err = writer.Write(email); err != nil {
+		return err
+	}
+	if err = writer.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
+
+// envelopeAddress return address without display name, "Fitter <bot@example.com>" -> "bot@example.com"
+func envelopeAddress(address string) string {
+	parsed, err := mail.ParseAddress(address)
+	if err != nil {
+		return address
+	}
+	return parsed.Address
+}
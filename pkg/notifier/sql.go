@@ -0,0 +1,262 @@
+package notifier
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/PxyUp/fitter/pkg/builder"
+	"github.com/PxyUp/fitter/pkg/config"
+	"github.com/PxyUp/fitter/pkg/database"
+	"github.com/PxyUp/fitter/pkg/logger"
+	"github.com/PxyUp/fitter/pkg/utils"
+	"github.com/tidwall/gjson"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultSQLBatchSize  = 100
+	defaultSQLColumnType = "TEXT"
+	sqlTimeout           = time.Minute
+)
+
+var (
+	_ Notifier      = &sqlNotifier{}
+	_ batchNotifier = &sqlNotifier{}
+
+	errSQLNoColumns = errors.New("sql notifier requires columns")
+)
+
+type sqlNotifier struct {
+	logger  logger.Logger
+	name    string
+	cfg     *config.SQLNotifierConfig
+	columns []string
+
+	mutex   sync.Mutex
+	created map[string]bool
+}
+
+func NewSQL(name string, cfg *config.SQLNotifierConfig) *sqlNotifier {
+	columns := make([]string, 0, len(cfg.Columns))
+	for column := range cfg.Columns {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+
+	return &sqlNotifier{
+		logger:  logger.Null,
+		name:    name,
+		cfg:     cfg,
+		columns: columns,
+		created: make(map[string]bool),
+	}
+}
+
+func (s *sqlNotifier) WithLogger(logger logger.Logger) *sqlNotifier {
+	s.logger = logger
+	return s
+}
+
+func (s *sqlNotifier) GetLogger() logger.Logger {
+	return s.logger
+}
+
+func (s *sqlNotifier) notify(record *singleRecord, input builder.Interfacable) error {
+	return s.notifyBatch([]*singleRecord{record}, input)
+}
+
+func (s *sqlNotifier) notifyBatch(records []*singleRecord, input builder.Interfacable) error {
+	if len(s.columns) == 0 {
+		return errSQLNoColumns
+	}
+
+	// records with error are not inserted, their errors are returned after other rows are inserted
+	var errs []error
+	var failed []*singleRecord
+	rows := make([]*singleRecord, 0, len(records))
+	for _, record := range records {
+		if record.Error != nil {
+			s.logger.Errorw("record with error is not inserted", "error", (*record.Error).Error())
+			errs = append(errs, *record.Error)
+			failed = append(failed, record)
+			continue
+		}
+		rows = append(rows, record)
+	}
+	if len(rows) == 0 {
+		return s.failedError(failed, errs)
+	}
+
+	dsn := utils.Format(s.cfg.DSN, nil, nil, input)
+	table := utils.Format(s.cfg.Table, nil, nil, input)
+	db, release, err := database.Get(s.cfg.Driver, dsn)
+	if err != nil {
+		s.logger.Errorw("unable to open database", "driver", string(s.cfg.Driver), "error", err.Error())
+		return err
+	}
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), sqlTimeout)
+	defer cancel()
+
+	if s.cfg.CreateTable {
+		if err = s.createTable(ctx, db, dsn, table); err != nil {
+			s.logger.Errorw("unable to create table", "table", table, "error", err.Error())
+			return err
+		}
+	}
+
+	batchSize := defaultSQLBatchSize
+	if s.cfg.BatchSize > 0 {
+		batchSize = int(s.cfg.BatchSize)
+	}
+
+	query := s.insertQuery(table)
+	for start := 0; start < len(rows); start += batchSize {
+		end := start + batchSize
+		if end > len(rows) {
+			end = len(rows)
+		}
+		if err = s.insertBatch(ctx, db, query, rows[start:end]); err != nil {
+			s.logger.Errorw("unable to insert rows", "table", table, "error", err.Error())
+			return err
+		}
+	}
+
+	return s.failedError(failed, errs)
+}
+
+// failedError return error of the records which are not inserted, they are not retried because error of the record is not changed
+func (s *sqlNotifier) failedError(failed []*singleRecord, errs []error) error {
+	if len(failed) == 0 {
+		return nil
+	}
+
+	return &recordsError{
+		records: failed,
+		err:     errors.Join(errs...),
+	}
+}
+
+func (s *sqlNotifier) createTable(ctx context.Context, db *sql.DB, dsn string, table string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.created[dsn+"|"+table] {
+		return nil
+	}
+
+	definitions := make([]string, len(s.columns))
+	for i, column := range s.columns {
+		columnType := defaultSQLColumnType
+		if s.cfg.ColumnTypes[column] != "" {
+			columnType = s.cfg.ColumnTypes[column]
+		}
+		definitions[i] = database.QuoteIdentifier(column) + " " + columnType
+	}
+	if len(s.cfg.Key) > 0 {
+		definitions = append(definitions, "PRIMARY KEY ("+quoteIdentifiers(s.cfg.Key)+")")
+	}
+
+	_, err := db.ExecContext(ctx, "CREATE TABLE IF NOT EXISTS "+database.QuoteIdentifier(table)+" ("+strings.Join(definitions, ", ")+")")
+	if err != nil {
+		return err
+	}
+
+	s.created[dsn+"|"+table] = true
+	return nil
+}
+
+// insertQuery build insert query, with key it is upsert which update not key columns on conflict
+func (s *sqlNotifier) insertQuery(table string) string {
+	placeholders := make([]string, len(s.columns))
+	for i := range s.columns {
+		placeholders[i] = database.Placeholder(s.cfg.Driver, i+1)
+	}
+
+	query := "INSERT INTO " + database.QuoteIdentifier(table) + " (" + quoteIdentifiers(s.columns) + ") VALUES (" + strings.Join(placeholders, ", ") + ")"
+	if len(s.cfg.Key) == 0 {
+		return query
+	}
+
+	var updates []string
+	for _, column := range s.columns {
+		if slices.Contains(s.cfg.Key, column) {
+			continue
+		}
+		quoted := database.QuoteIdentifier(column)
+		updates = append(updates, quoted+" = excluded."+quoted)
+	}
+
+	query += " ON CONFLICT (" + quoteIdentifiers(s.cfg.Key) + ")"
+	if len(updates) == 0 {
+		return query + " DO NOTHING"
+	}
+	return query + " DO UPDATE SET " + strings.Join(updates, ", ")
+}
+
+func (s *sqlNotifier) insertBatch(ctx context.Context, db *sql.DB, query string, records []*singleRecord) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	stmt, err := tx.PrepareContext(ctx, query)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	defer stmt.Close()
+
+	for _, record := range records {
+		if _, err = stmt.ExecContext(ctx, s.values(record)...); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (s *sqlNotifier) values(record *singleRecord) []interface{} {
+	body := gjson.ParseBytes(record.Body)
+	values := make([]interface{}, len(s.columns))
+	for i, column := range s.columns {
+		value := body
+		if path := s.cfg.Columns[column]; path != "" {
+			value = body.Get(path)
+		}
+		values[i] = sqlValue(value)
+	}
+	return values
+}
+
+func sqlValue(value gjson.Result) interface{} {
+	switch value.Type {
+	case gjson.Null:
+		return nil
+	case gjson.False, gjson.True:
+		return value.Bool()
+	case gjson.Number:
+		if value.Num == float64(value.Int()) {
+			return value.Int()
+		}
+		return value.Num
+	case gjson.String:
+		return value.String()
+	default:
+		return value.Raw
+	}
+}
+
+func quoteIdentifiers(names []string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = database.QuoteIdentifier(name)
+	}
+	return strings.Join(quoted, ", ")
+}
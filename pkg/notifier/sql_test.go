@@ -0,0 +1,95 @@
+package notifier_test
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/PxyUp/fitter/pkg/config"
+	"github.com/PxyUp/fitter/pkg/database"
+	"github.com/PxyUp/fitter/pkg/logger"
+	"github.com/PxyUp/fitter/pkg/notifier"
+	"github.com/PxyUp/fitter/pkg/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path"
+	"testing"
+	"time"
+)
+
+func result(raw string) *parser.ParseResult {
+	return &parser.ParseResult{
+		Json:      raw,
+		RawResult: json.RawMessage(raw),
+	}
+}
+
+func TestSQLNotifier(t *testing.T) {
+	dsn := path.Join(t.TempDir(), "result.db")
+	sqlNotifier := notifier.NewSQL("products", &config.SQLNotifierConfig{
+		Driver: config.SQLite,
+		DSN:    dsn,
+		Table:  "products",
+		Columns: map[string]string{
+			"sku":   "sku",
+			"price": "price.value",
+			"tags":  "tags",
+		},
+		Key:         []string{"sku"},
+		CreateTable: true,
+		ColumnTypes: map[string]string{
+			"price": "REAL",
+		},
+		BatchSize: 2,
+	})
+
+	require.NoError(t, notifier.Inform(sqlNotifier, "products", result(`[
+		{"sku": "A-1", "price": {"value": 10.5}, "tags": ["new"]},
+		{"sku": "B-2", "price": {"value": 20}},
+		{"sku": "C-3", "price": {"value": 30}}
+	]`), nil, true, logger.Null, nil))
+	require.NoError(t, notifier.Inform(sqlNotifier, "products", result(`{"sku": "B-2", "price": {"value": 25}, "tags": []}`), nil, false, logger.Null, nil))
+
+	// result with error is not inserted, error is returned for retry and dead letter
+	assert.ErrorContains(t, notifier.Inform(sqlNotifier, "products", nil, errors.New("timeout"), false, logger.Null, nil), "timeout")
+
+	db, release, err := database.Get(config.SQLite, dsn)
+	require.NoError(t, err)
+	defer release()
+	rows, err := db.Query(`SELECT sku, price, tags FROM products ORDER BY sku`)
+	require.NoError(t, err)
+	defer rows.Close()
+
+	records, err := database.Rows(rows)
+	require.NoError(t, err)
+	raw, err := json.Marshal(records)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[
+		{"sku": "A-1", "price": 10.5, "tags": "[\"new\"]"},
+		{"sku": "B-2", "price": 25, "tags": "[]"},
+		{"sku": "C-3", "price": 30, "tags": null}
+	]`, string(raw))
+}
+
+func TestSQLNotifier_Delivery(t *testing.T) {
+	dir := t.TempDir()
+	deliveryNotifier := notifier.WithDelivery(notifier.NewSQL("products", &config.SQLNotifierConfig{
+		Driver:      config.SQLite,
+		DSN:         path.Join(dir, "result.db"),
+		Table:       "products",
+		Columns:     map[string]string{"sku": "sku"},
+		CreateTable: true,
+	}), "products", &config.DeliveryConfig{
+		Attempts:   3,
+		Backoff:    1000,
+		DeadLetter: path.Join(dir, "dead_letter"),
+	})
+
+	// record with error is written into dead letter without retries
+	start := time.Now()
+	assert.ErrorContains(t, notifier.Inform(deliveryNotifier, "products", nil, errors.New("timeout"), false, logger.Null, nil), "timeout")
+	assert.Less(t, time.Since(start), time.Second)
+
+	entries, err := os.ReadDir(path.Join(dir, "dead_letter"))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
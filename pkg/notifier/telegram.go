@@ -1,14 +1,19 @@
 package notifier
 
 import (
-	"bytes"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/PxyUp/fitter/pkg/builder"
 	"github.com/PxyUp/fitter/pkg/config"
 	"github.com/PxyUp/fitter/pkg/http_client"
 	"github.com/PxyUp/fitter/pkg/logger"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"strings"
+)
+
+const (
+	telegramTextLimit    = 4096
+	telegramCaptionLimit = 1024
 )
 
 type telegramBot struct {
@@ -18,28 +23,12 @@ type telegramBot struct {
 }
 
 func (t *telegramBot) notify(record *singleRecord, input builder.Interfacable) error {
-	var msg []byte
-	value := recordToInterfacable(record)
-	if t.cfg.OnlyMsg {
-		if record.Error != nil {
-			msg = value.Raw()
-		} else {
-			msg = record.Body
-		}
-	} else {
-		body, err := json.Marshal(record)
-		if err != nil {
-			return err
-		}
-
-		msg = body
-	}
-
-	if t.cfg.Pretty {
-		return t.sendMessage(t.prettyMsg(string(msg)), record, input)
+	msg, err := recordMessage(record, t.cfg.OnlyMsg, t.cfg.Pretty)
+	if err != nil {
+		return err
 	}
 
-	return t.sendMessage(string(msg), record, input)
+	return t.sendMessage(msg, record, input)
 }
 
 var (
@@ -66,30 +55,86 @@ func (t *telegramBot) sendMessage(msg string, record *singleRecord, input builde
 	}
 	botApi.Client = http_client.GetDefaultClient()
 
+	var media tgbotapi.RequestFileData
+	isPhoto := false
+	if t.cfg.Photo != "" {
+		media = telegramFile(formatWithRecord(t.cfg.Photo, record, input))
+		isPhoto = true
+	} else if t.cfg.Document != "" {
+		media = telegramFile(formatWithRecord(t.cfg.Document, record, input))
+	}
+
+	var errs []error
 	for _, id := range t.cfg.UsersId {
-		msgForSend := tgbotapi.NewMessage(id, msg)
-		_, errSend := botApi.Send(msgForSend)
-		if errSend != nil {
-			t.logger.Errorw("unable to send result", "error", errSend.Error(), "userId", fmt.Sprintf("%d", id))
+		for _, chattable := range t.chattables(id, msg, media, isPhoto) {
+			_, errSend := botApi.Send(chattable)
+			if errSend != nil {
+				t.logger.Errorw("unable to send result", "error", errSend.Error(), "userId", fmt.Sprintf("%d", id))
+				errs = append(errs, errSend)
+				break
+			}
 		}
 	}
 
-	return nil
+	return errors.Join(errs...)
 }
 
-func (o *telegramBot) GetLogger() logger.Logger {
-	return o.logger
+// chattables return media with message as caption, message is sent separately if it is longer than caption limit
+func (t *telegramBot) chattables(id int64, msg string, media tgbotapi.RequestFileData, isPhoto bool) []tgbotapi.Chattable {
+	var result []tgbotapi.Chattable
+
+	if media != nil {
+		caption := ""
+		if len([]rune(msg)) <= telegramCaptionLimit {
+			caption = msg
+			msg = ""
+		}
+
+		if isPhoto {
+			photo := tgbotapi.NewPhoto(id, media)
+			photo.Caption = caption
+			photo.ParseMode = t.cfg.ParseMode
+			result = append(result, photo)
+		} else {
+			document := tgbotapi.NewDocument(id, media)
+			document.Caption = caption
+			document.ParseMode = t.cfg.ParseMode
+			result = append(result, document)
+		}
+
+		if msg == "" {
+			return result
+		}
+	}
+
+	parts := messageParts(msg, telegramTextLimit, false)
+	parseMode := t.cfg.ParseMode
+	if len(parts) > 1 && parseMode != "" {
+		// split can cut through entities or tags, telegram reject such parts, so they are sent as plain text
+		t.logger.Infow("message is split and sent without parse mode", "parts", fmt.Sprintf("%d", len(parts)), "parse_mode", parseMode)
+		parseMode = ""
+	}
+
+	for _, part := range parts {
+		msgForSend := tgbotapi.NewMessage(id, part)
+		msgForSend.ParseMode = parseMode
+		result = append(result, msgForSend)
+	}
+
+	return result
 }
 
-func (t *telegramBot) prettyMsg(msg string) string {
-	if !t.cfg.Pretty {
-		return msg
+// telegramFile return file by url or local path, nil for empty value
+func telegramFile(value string) tgbotapi.RequestFileData {
+	if value == "" {
+		return nil
 	}
-	var prettyJSON bytes.Buffer
-	errPretty := json.Indent(&prettyJSON, []byte(msg), "", " ")
-	if errPretty != nil {
-		t.logger.Errorw("unable to prettify result", "error", errPretty.Error())
-		return msg
+	if strings.HasPrefix(value, "http://") || strings.HasPrefix(value, "https://") {
+		return tgbotapi.FileURL(value)
 	}
-	return prettyJSON.String()
+	return tgbotapi.FilePath(value)
+}
+
+func (o *telegramBot) GetLogger() logger.Logger {
+	return o.logger
 }
@@ -0,0 +1,105 @@
+package notifier
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/PxyUp/fitter/pkg/builder"
+	"github.com/PxyUp/fitter/pkg/config"
+	"github.com/PxyUp/fitter/pkg/utils"
+)
+
+var (
+	_ Notifier      = &templateNotifier{}
+	_ batchNotifier = &templateNotifier{}
+)
+
+// templateNotifier render each record with Go template and send result of it like string
+type templateNotifier struct {
+	Notifier
+	tmpl *utils.Template
+}
+
+func WithTemplate(notifier Notifier, name string, text string, mode config.TemplateMode) (Notifier, error) {
+	tmpl, err := utils.NewTemplate(name, text, mode)
+	if err != nil {
+		return nil, err
+	}
+
+	return &templateNotifier{
+		Notifier: notifier,
+		tmpl:     tmpl,
+	}, nil
+}
+
+func (t *templateNotifier) notify(record *singleRecord, input builder.Interfacable) error {
+	rendered, err := t.render(record, input)
+	if err != nil {
+		return err
+	}
+	return t.Notifier.notify(rendered, input)
+}
+
+func (t *templateNotifier) notifyBatch(records []*singleRecord, input builder.Interfacable) error {
+	rendered := make([]*singleRecord, len(records))
+	for i, record := range records {
+		renderedRecord, err := t.render(record, input)
+		if err != nil {
+			return err
+		}
+		rendered[i] = renderedRecord
+	}
+
+	if batch, ok := t.Notifier.(batchNotifier); ok {
+		return batch.notifyBatch(rendered, input)
+	}
+
+	for _, record := range rendered {
+		if err := t.Notifier.notify(record, input); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *templateNotifier) render(record *singleRecord, input builder.Interfacable) (*singleRecord, error) {
+	data := &utils.TemplateData{
+		Name: record.Name,
+		Raw:  string(record.Body),
+	}
+	if record.Index != nil {
+		data.Index = int(*record.Index)
+	}
+	if record.Error != nil {
+		data.Error = (*record.Error).Error()
+	}
+	if len(record.Body) > 0 {
+		result, err := utils.DecodeJson(record.Body)
+		if err != nil {
+			t.GetLogger().Errorw("unable to decode result for template", "error", err.Error())
+			return nil, err
+		}
+		data.Result = result
+	}
+	if input != nil {
+		data.Input = input.ToInterface()
+	}
+
+	text, err := t.tmpl.Execute(data)
+	if err != nil {
+		t.GetLogger().Errorw("unable to execute template", "error", err.Error())
+		return nil, err
+	}
+
+	var body bytes.Buffer
+	encoder := json.NewEncoder(&body)
+	encoder.SetEscapeHTML(false)
+	if err = encoder.Encode(text); err != nil {
+		return nil, err
+	}
+
+	return &singleRecord{
+		Name:  record.Name,
+		Body:  bytes.TrimSuffix(body.Bytes(), []byte("\n")),
+		Index: record.Index,
+	}, nil
+}
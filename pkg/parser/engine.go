@@ -29,6 +29,11 @@ type engine struct {
 	connector connectors.Connector
 	parser    Factory
 	logger    logger.Logger
+	url       string
+}
+
+type pageURLSetter interface {
+	SetPageURL(pageURL string)
 }
 
 type null struct {
@@ -42,13 +47,20 @@ func (e *engine) Get(model *config.Model, parsedValue builder.Interfacable, inde
 	if model == nil {
 		return nil, errMissingModelConfig
 	}
-	body, err := e.connector.Get(parsedValue, index, input)
+	pageURL, body, err := connectors.GetWithURL(e.connector, parsedValue, index, input)
 	if err != nil {
 		e.logger.Errorw("connector return error during fetch data", "error", err.Error())
 		return nil, err
 	}
 	e.logger.Debugw("connector answer", "content", string(body))
-	return e.parser(body, e.logger).Parse(model, input)
+	parser := e.parser(body, e.logger)
+	if pageURL == "" && e.url != "" {
+		pageURL = utils.Format(e.url, parsedValue, index, input)
+	}
+	if setter, ok := parser.(pageURLSetter); ok && pageURL != "" {
+		setter.SetPageURL(pageURL)
+	}
+	return parser.Parse(model, input)
 }
 
 func NewEngine(cfg *config.ConnectorConfig, logger logger.Logger) Engine {
@@ -64,7 +76,22 @@ func NewEngine(cfg *config.ConnectorConfig, logger logger.Logger) Engine {
 		connector = connectors.NewStatic(cfg.StaticConfig).WithLogger(logger.With("connector", "static"))
 	}
 	if cfg.ServerConfig != nil {
-		connector = connectors.NewAPI(cfg.Url, cfg.ServerConfig, nil).WithLogger(logger.With("connector", "server"))
+		connector = connectors.NewAPI(cfg.Url, cfg.ServerConfig, nil).WithObjectBuilder(buildRequestObject).WithLogger(logger.With("connector", "server"))
+	}
+	if cfg.GraphQLConfig != nil {
+		connector = connectors.NewGraphQL(cfg.Url, cfg.GraphQLConfig).WithLogger(logger.With("connector", "graphql"))
+	}
+	if cfg.WebSocketConfig != nil {
+		connector = connectors.NewWebSocket(cfg.Url, cfg.WebSocketConfig).WithLogger(logger.With("connector", "websocket"))
+	}
+	if cfg.SSEConfig != nil {
+		connector = connectors.NewSSE(cfg.Url, cfg.SSEConfig).WithLogger(logger.With("connector", "sse"))
+	}
+	if cfg.GRPCConfig != nil {
+		connector = connectors.NewGRPC(cfg.GRPCConfig).WithLogger(logger.With("connector", "grpc"))
+	}
+	if cfg.SQLConfig != nil {
+		connector = connectors.NewSQL(cfg.SQLConfig).WithLogger(logger.With("connector", "sql"))
 	}
 	if cfg.BrowserConfig != nil {
 		connector = connectors.NewBrowser(cfg.Url, cfg.BrowserConfig).WithLogger(logger.With("connector", "browser"))
@@ -118,6 +145,9 @@ func NewEngine(cfg *config.ConnectorConfig, logger logger.Logger) Engine {
 	if cfg.ResponseType == config.XML {
 		parserFactory = XMLFactory
 	}
+	if cfg.ResponseType == config.Text {
+		parserFactory = TextFactory
+	}
 
 	if connector == nil || parserFactory == nil {
 		return nullEngine
@@ -125,6 +155,10 @@ func NewEngine(cfg *config.ConnectorConfig, logger logger.Logger) Engine {
 
 	connector = connectors.WithAttempts(connector, cfg.Attempts)
 
+	if cfg.DocumentConfig != nil {
+		connector = connectors.WithDocument(connector, cfg.DocumentConfig).WithLogger(logger.With("connector", "document"))
+	}
+
 	if cfg.NullOnError {
 		connector = connectors.NullSafe(connector)
 	}
@@ -133,5 +167,6 @@ func NewEngine(cfg *config.ConnectorConfig, logger logger.Logger) Engine {
 		connector: connector,
 		parser:    parserFactory,
 		logger:    logger,
+		url:       cfg.Url,
 	}
 }
@@ -1,13 +1,17 @@
 package parser
 
 import (
+	"encoding/json"
 	"github.com/PxyUp/fitter/pkg/builder"
 	"github.com/PxyUp/fitter/pkg/config"
 	"github.com/PxyUp/fitter/pkg/connectors"
 	"github.com/PxyUp/fitter/pkg/http_client"
 	"github.com/PxyUp/fitter/pkg/logger"
+	"github.com/PxyUp/fitter/pkg/storage"
 	"github.com/PxyUp/fitter/pkg/utils"
+	"io"
 	"mime"
+	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
@@ -35,6 +39,21 @@ func CreateFileStorageField(parsedValue builder.Interfacable, index *uint32, inp
 	destinationFileName := utils.Format(cfg.FileName, parsedValue, index, input)
 	destinationPath := utils.Format(cfg.Path, parsedValue, index, input)
 
+	if cfg.Format != "" {
+		row := []byte(content)
+		if cfg.Content == "" && len(cfg.Raw) == 0 {
+			row = parsedValue.Raw()
+		}
+		return storage.WriteRecords(cfg, destinationFileName, destinationPath, []json.RawMessage{row}, utils.Format(cfg.ContentType, parsedValue, index, input), input, logger)
+	}
+
+	if cfg.S3 != nil {
+		if cfg.Append {
+			return "", storage.ErrS3Append
+		}
+		return storage.Upload(cfg.S3, storage.Key(destinationPath, destinationFileName), []byte(content), utils.Format(cfg.ContentType, parsedValue, index, input), cfg.Gzip, input, logger)
+	}
+
 	return utils.CreateFileWithContent([]byte(content), destinationFileName, destinationPath, os.ModePerm, cfg.Append, logger)
 }
 
@@ -43,33 +62,54 @@ func ProcessFileField(parsedValue builder.Interfacable, index *uint32, input bui
 	destinationPath := utils.Format(field.Path, parsedValue, index, input)
 	destinationURL := utils.Format(field.Url, parsedValue, index, input)
 
-	connector := connectors.NewAPI(destinationURL, field.Config, http_client.GetDefaultClient()).WithLogger(logger.With("connector", "file"))
+	connector := connectors.NewAPI(destinationURL, field.Config, http_client.GetDefaultClient()).WithObjectBuilder(buildRequestObject).WithLogger(logger.With("connector", "file"))
 
-	headers, body, err := connector.GetWithHeaders(parsedValue, index, input)
-	if err != nil {
-		logger.Errorw("unable to get file from url", "url", destinationURL, "error", err.Error())
-		return "", err
+	var connectorLimit int64
+	if field.Config != nil {
+		connectorLimit = field.Config.MaxBodySize
 	}
+	limit := utils.BodyLimit(connectorLimit)
 
-	if destinationFileName == "" {
-		_, params, errHeader := mime.ParseMediaType(headers.Get("Content-Disposition"))
-		if errHeader != nil {
-			filename, errFromUrl := filenameFromUrl(destinationURL)
-			if errFromUrl != nil {
-				logger.Errorw("unable to set filename for file", "url", destinationURL, "header_value", headers.Get("Content-Disposition"), "error_url", errFromUrl.Error(), "err_header", errHeader.Error())
-				return "", errFromUrl
+	var filePath string
+	_, err := connector.Stream(parsedValue, index, input, func(resp *http.Response, body io.Reader) error {
+		fileName := destinationFileName
+		if fileName == "" {
+			_, params, errHeader := mime.ParseMediaType(resp.Header.Get("Content-Disposition"))
+			if errHeader != nil {
+				filename, errFromUrl := filenameFromUrl(destinationURL)
+				if errFromUrl != nil {
+					logger.Errorw("unable to set filename for file", "url", destinationURL, "header_value", resp.Header.Get("Content-Disposition"), "error_url", errFromUrl.Error(), "err_header", errHeader.Error())
+					return errFromUrl
+				}
+				fileName = filename
+			}
+			if errHeader == nil {
+				fileName = params["filename"]
 			}
-			destinationFileName = filename
 		}
-		if errHeader == nil {
-			destinationFileName = params["filename"]
+
+		if fileName == "" {
+			logger.Errorw("missing file name for file", "url", destinationURL, "header_value", resp.Header.Get("Content-Disposition"), "error", utils.ErrMissingFileName.Error())
+			return utils.ErrMissingFileName
 		}
-	}
 
-	if destinationFileName == "" {
-		logger.Errorw("missing file name for file", "url", destinationURL, "header_value", headers.Get("Content-Disposition"), "error", utils.ErrMissingFileName.Error())
-		return "", utils.ErrMissingFileName
+		var errCreate error
+		if field.S3 != nil {
+			contentType := utils.Format(field.ContentType, parsedValue, index, input)
+			if contentType == "" {
+				contentType = resp.Header.Get("Content-Type")
+			}
+			filePath, errCreate = storage.UploadFromReader(field.S3, storage.Key(destinationPath, fileName), body, contentType, field.Gzip, limit, input, logger)
+			return errCreate
+		}
+
+		filePath, errCreate = utils.CreateFileFromReader(body, fileName, destinationPath, os.ModePerm, limit, logger)
+		return errCreate
+	})
+	if err != nil {
+		logger.Errorw("unable to get file from url", "url", destinationURL, "error", err.Error())
+		return "", err
 	}
 
-	return utils.CreateFileWithContent(body, destinationFileName, destinationPath, os.ModePerm, false, logger)
+	return filePath, nil
 }
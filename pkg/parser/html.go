@@ -19,11 +19,7 @@ func selectionToArray(parent *goquery.Selection) []*goquery.Selection {
 	return tmp
 }
 
-func htmlFillUpBaseField(source *goquery.Selection, field *config.BaseField) builder.Interfacable {
-	if source.Length() <= 0 {
-		return builder.NullValue
-	}
-
+func htmlFillUpBaseField(source *goquery.Selection, field *config.BaseField, text string) builder.Interfacable {
 	if field.Type == config.HtmlString {
 		htmlString, err := source.Html()
 		if err != nil {
@@ -32,18 +28,6 @@ func htmlFillUpBaseField(source *goquery.Selection, field *config.BaseField) bui
 		return builder.String(htmlString)
 	}
 
-	var text string
-
-	if field.HTMLAttribute != "" {
-		attrValue, attrExists := source.First().Attr(field.HTMLAttribute)
-		if !attrExists {
-			return builder.NullValue
-		}
-		text = attrValue
-	} else {
-		text = source.First().Text()
-	}
-
 	switch field.Type {
 	case config.Null:
 		return builder.NullValue
@@ -72,26 +56,34 @@ func htmlFillUpBaseField(source *goquery.Selection, field *config.BaseField) bui
 
 func NewHTML(body []byte, logger logger.Logger) *engineParser[*goquery.Selection] {
 	document, _ := goquery.NewDocumentFromReader(bytes.NewReader(body))
+	baseHref, _ := document.Find("base[href]").First().Attr("href")
 
 	return &engineParser[*goquery.Selection]{
 		getText: func(r *goquery.Selection) string {
 			return r.First().Text()
 		},
+		getAttribute: func(r *goquery.Selection, attribute string) (string, bool) {
+			return r.First().Attr(attribute)
+		},
+		isEmpty: func(r *goquery.Selection) bool {
+			return r.Length() <= 0
+		},
 		parserBody: document.Selection,
+		baseHref:   baseHref,
 		logger:     logger,
 		getAll: func(parent *goquery.Selection, path string) []*goquery.Selection {
 			if path == "" {
 				return selectionToArray(parent)
 			}
 
-			res := parent.Find(path)
+			res := selectHTML(parent, path, logger)
 			return selectionToArray(res)
 		},
 		getOne: func(parent *goquery.Selection, path string) *goquery.Selection {
 			if path == "" {
 				return parent
 			}
-			return parent.Find(path)
+			return selectHTML(parent, path, logger)
 		},
 		customFillUpBaseField: htmlFillUpBaseField,
 	}
@@ -0,0 +1,271 @@
+package parser
+
+import (
+	"github.com/PuerkitoBio/goquery"
+	"github.com/PxyUp/fitter/pkg/logger"
+	"github.com/antchfx/htmlquery"
+	"golang.org/x/net/html"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	xpathSelectorPrefix = "xpath:"
+
+	pseudoHasText     = "has-text"
+	pseudoNthMatch    = "nth-match"
+	pseudoParent      = "parent"
+	pseudoNextSibling = "next-sibling"
+	pseudoPrevSibling = "prev-sibling"
+)
+
+var (
+	htmlSelectorCache sync.Map
+	// invalidXPaths keep invalid xpath selectors which are already logged
+	invalidXPaths sync.Map
+
+	pseudoWithArgument = []string{pseudoHasText, pseudoNthMatch}
+	pseudoWithoutArg   = []string{pseudoParent, pseudoNextSibling, pseudoPrevSibling}
+)
+
+type selectorStep struct {
+	css    string
+	pseudo string
+	arg    string
+}
+
+func isIdentChar(ch byte) bool {
+	return ch == '-' || ch == '_' || (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z') || (ch >= '0' && ch <= '9')
+}
+
+// closingParen return index of the paren which close paren opened before start
+func closingParen(path string, start int) int {
+	depth := 1
+	var quote byte
+	for i := start; i < len(path); i++ {
+		ch := path[i]
+		if quote != 0 {
+			if ch == quote {
+				quote = 0
+			}
+			continue
+		}
+		switch ch {
+		case '\'', '"':
+			quote = ch
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func unquote(arg string) string {
+	arg = strings.TrimSpace(arg)
+	if len(arg) >= 2 && (arg[0] == '\'' || arg[0] == '"') && arg[len(arg)-1] == arg[0] {
+		return arg[1 : len(arg)-1]
+	}
+	return arg
+}
+
+func parsePseudo(path string, i int) (step *selectorStep, end int) {
+	rest := path[i+1:]
+	for _, name := range pseudoWithArgument {
+		if strings.HasPrefix(rest, name+"(") {
+			start := i + 1 + len(name) + 1
+			closing := closingParen(path, start)
+			if closing < 0 {
+				return nil, i
+			}
+			return &selectorStep{pseudo: name, arg: unquote(path[start:closing])}, closing + 1
+		}
+	}
+
+	for _, name := range pseudoWithoutArg {
+		if strings.HasPrefix(rest, name) && (len(rest) == len(name) || !isIdentChar(rest[len(name)])) {
+			return &selectorStep{pseudo: name}, i + 1 + len(name)
+		}
+	}
+
+	return nil, i
+}
+
+// parseHTMLSelector split path into css selectors and fitter pseudo-selectors
+func parseHTMLSelector(path string) []*selectorStep {
+	if cached, ok := htmlSelectorCache.Load(path); ok {
+		return cached.([]*selectorStep)
+	}
+
+	var steps []*selectorStep
+	var css strings.Builder
+	depth := 0
+	var quote byte
+	for i := 0; i < len(path); i++ {
+		ch := path[i]
+		if quote != 0 {
+			if ch == quote {
+				quote = 0
+			}
+			css.WriteByte(ch)
+			continue
+		}
+
+		switch ch {
+		case '\'', '"':
+			quote = ch
+		case '(', '[':
+			depth++
+		case ')', ']':
+			depth--
+		case ':':
+			if depth == 0 {
+				if step, end := parsePseudo(path, i); step != nil {
+					steps = append(steps, &selectorStep{css: css.String()}, step)
+					css.Reset()
+					i = end - 1
+					continue
+				}
+			}
+		}
+		css.WriteByte(ch)
+	}
+	steps = append(steps, &selectorStep{css: css.String()})
+
+	htmlSelectorCache.Store(path, steps)
+	return steps
+}
+
+// splitCompound split selector into first compound selector and rest of the selector
+func splitCompound(selector string) (string, string) {
+	depth := 0
+	var quote byte
+	for i := 0; i < len(selector); i++ {
+		ch := selector[i]
+		if quote != 0 {
+			if ch == quote {
+				quote = 0
+			}
+			continue
+		}
+		switch ch {
+		case '\'', '"':
+			quote = ch
+		case '(', '[':
+			depth++
+		case ')', ']':
+			depth--
+		case ' ', '\t', '\n', '>', '+', '~':
+			if depth == 0 {
+				return selector[:i], selector[i:]
+			}
+		}
+	}
+	return selector, ""
+}
+
+func applyCSS(selection *goquery.Selection, css string, first bool) *goquery.Selection {
+	trimmed := strings.TrimLeft(css, " \t\n")
+	if strings.TrimSpace(trimmed) == "" {
+		return selection
+	}
+
+	switch trimmed[0] {
+	case '>', '+', '~':
+		compound, rest := splitCompound(strings.TrimSpace(trimmed[1:]))
+		switch trimmed[0] {
+		case '>':
+			selection = selection.ChildrenFiltered(compound)
+		case '+':
+			selection = selection.NextFiltered(compound)
+		case '~':
+			selection = selection.NextAllFiltered(compound)
+		}
+		return applyCSS(selection, rest, false)
+	}
+
+	if first || len(trimmed) != len(css) {
+		return selection.Find(strings.TrimSpace(trimmed))
+	}
+
+	return selection.Filter(strings.TrimSpace(trimmed))
+}
+
+func normalizeSpaces(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
+
+func applyPseudo(selection *goquery.Selection, step *selectorStep) *goquery.Selection {
+	switch step.pseudo {
+	case pseudoHasText:
+		expected := normalizeSpaces(step.arg)
+		return selection.FilterFunction(func(_ int, s *goquery.Selection) bool {
+			return normalizeSpaces(s.Text()) == expected
+		})
+	case pseudoNthMatch:
+		n, err := strconv.Atoi(strings.TrimSpace(step.arg))
+		if err != nil || n == 0 {
+			return selection.Slice(0, 0)
+		}
+		if n > 0 {
+			return selection.Eq(n - 1)
+		}
+		return selection.Eq(n)
+	case pseudoParent:
+		return selection.Parent()
+	case pseudoNextSibling:
+		return selection.Next()
+	case pseudoPrevSibling:
+		return selection.Prev()
+	}
+
+	return selection
+}
+
+func selectXPath(parent *goquery.Selection, expr string, logger logger.Logger) *goquery.Selection {
+	var nodes []*html.Node
+	for _, node := range parent.Nodes {
+		found, err := htmlquery.QueryAll(node, expr)
+		if err != nil {
+			if _, logged := invalidXPaths.LoadOrStore(expr, struct{}{}); !logged {
+				logger.Errorw("invalid xpath selector", "xpath", expr, "error", err.Error())
+			}
+			return parent.Slice(0, 0)
+		}
+		nodes = append(nodes, found...)
+	}
+
+	// empty selection must not share nodes with parent, otherwise AddNodes overwrite them
+	selection := parent.Slice(0, 0)
+	selection.Nodes = nil
+	return selection.AddNodes(nodes...)
+}
+
+// selectHTML find elements by css selector with support of fitter pseudo-selectors
+// (:has-text(), :nth-match(), :parent, :next-sibling, :prev-sibling) or by xpath with "xpath:" prefix
+func selectHTML(parent *goquery.Selection, path string, logger logger.Logger) *goquery.Selection {
+	if strings.HasPrefix(path, xpathSelectorPrefix) {
+		return selectXPath(parent, strings.TrimPrefix(path, xpathSelectorPrefix), logger)
+	}
+
+	steps := parseHTMLSelector(path)
+	if len(steps) == 1 {
+		return parent.Find(path)
+	}
+
+	selection := parent
+	for i, step := range steps {
+		if step.pseudo != "" {
+			selection = applyPseudo(selection, step)
+			continue
+		}
+		selection = applyCSS(selection, step.css, i == 0)
+	}
+
+	return selection
+}
@@ -9,6 +9,8 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
 )
@@ -399,3 +401,226 @@ func (s *HTMLV2ParserArraySuite) Test_ParseNestedObject() {
 	assert.NoError(s.T(), err)
 	assert.JSONEq(s.T(), "{\"player\": {\"latitude\": 3.120000,\"player_meal\": [{\"my_price\": \"first\"},{\"my_price\": \"second\"},{\"my_price\": null},{\"my_price\": null},{\"my_price\": null},{\"my_price\": null},{\"my_price\": null},{\"my_price\": null},{\"my_price\": null}],\"name\": \"HTML Headings\",\"isActive\": true,\"null\": null}}\n", res.ToJson())
 }
+
+const specTable = `<html><body>
+<table class="spec">
+	<tr><th>Brand</th><td>Acme</td></tr>
+	<tr><th>Price</th><td class="value"><a href="/price/1" data-id="p1">100</a></td></tr>
+	<tr><th>Price per unit</th><td class="value">5</td></tr>
+</table>
+<ul><li>first</li><li>second</li><li>third</li></ul>
+</body></html>`
+
+func (s *HTMLV2ParserArraySuite) Test_PseudoSelectors() {
+	res, err := parser.NewHTML([]byte(specTable), logger.Null).Parse(&config.Model{
+		ObjectConfig: &config.ObjectConfig{
+			Fields: map[string]*config.Field{
+				"price": {
+					BaseField: &config.BaseField{
+						Type: config.Int,
+						Path: "th:has-text('Price'):next-sibling",
+					},
+				},
+				"price_link": {
+					BaseField: &config.BaseField{
+						Type:          config.String,
+						Path:          "th:has-text(\"Price\"):next-sibling > a",
+						HTMLAttribute: "href",
+					},
+				},
+				"contains": {
+					BaseField: &config.BaseField{
+						Type: config.String,
+						Path: "th:contains('per unit'):parent td",
+					},
+				},
+				"brand_label": {
+					BaseField: &config.BaseField{
+						Type: config.String,
+						Path: "td:has-text(Acme):prev-sibling",
+					},
+				},
+				"second": {
+					BaseField: &config.BaseField{
+						Type: config.String,
+						Path: "li:nth-match(2)",
+					},
+				},
+				"last": {
+					BaseField: &config.BaseField{
+						Type: config.String,
+						Path: "li:nth-match(-1)",
+					},
+				},
+				"filtered": {
+					BaseField: &config.BaseField{
+						Type: config.Int,
+						Path: "th:has-text('Price per unit'):next-sibling.value",
+					},
+				},
+			},
+		},
+	}, nil)
+	assert.NoError(s.T(), err)
+	assert.JSONEq(s.T(), `{"price": 100, "price_link": "/price/1", "contains": "5", "brand_label": "Brand", "second": "second", "last": "third", "filtered": 5}`, res.ToJson())
+}
+
+func (s *HTMLV2ParserArraySuite) Test_XPathPrefix() {
+	res, err := parser.NewHTML([]byte(specTable), logger.Null).Parse(&config.Model{
+		ObjectConfig: &config.ObjectConfig{
+			Fields: map[string]*config.Field{
+				"id": {
+					BaseField: &config.BaseField{
+						Type:          config.String,
+						Path:          "xpath://th[text()='Price']/following-sibling::td/a",
+						HTMLAttribute: "data-id",
+					},
+				},
+				"items": {
+					ArrayConfig: &config.ArrayConfig{
+						RootPath: "ul",
+						ItemConfig: &config.ObjectConfig{
+							Field: &config.BaseField{
+								Type: config.String,
+								Path: "xpath:./li[last()]",
+							},
+						},
+					},
+				},
+			},
+		},
+	}, nil)
+	assert.NoError(s.T(), err)
+	assert.JSONEq(s.T(), `{"id": "p1", "items": ["third"]}`, res.ToJson())
+}
+
+const linksPage = `<html><head><base href="/catalog/"></head><body>
+<a class="item" href="item/123" data-id="123"> First item </a>
+<img src="../img/1.png" data-src="../img/1-large.png">
+</body></html>`
+
+func (s *HTMLV2ParserArraySuite) Test_Attributes() {
+	p := parser.NewHTML([]byte(linksPage), logger.Null)
+	p.SetPageURL("https://example.com/shop/index.html")
+	res, err := p.Parse(&config.Model{
+		ObjectConfig: &config.ObjectConfig{
+			Fields: map[string]*config.Field{
+				"link": {
+					BaseField: &config.BaseField{
+						Path:       "a.item",
+						Attributes: []string{"#text", "href", "data-id", "title"},
+						ResolveURL: true,
+					},
+				},
+				"image": {
+					BaseField: &config.BaseField{
+						Type:       config.String,
+						Path:       "img",
+						Attribute:  "src",
+						ResolveURL: true,
+					},
+				},
+				"lazy": {
+					BaseField: &config.BaseField{
+						Type:       config.String,
+						Path:       "img",
+						Attribute:  "data-src",
+						ResolveURL: true,
+					},
+				},
+				"relative": {
+					BaseField: &config.BaseField{
+						Type:      config.String,
+						Path:      "a.item",
+						Attribute: "href",
+					},
+				},
+				"missing": {
+					BaseField: &config.BaseField{
+						Path:       "a.missing",
+						Attributes: []string{"href"},
+					},
+				},
+			},
+		},
+	}, nil)
+	assert.NoError(s.T(), err)
+	assert.JSONEq(s.T(), `{
+		"link": {"#text": "First item", "href": "https://example.com/catalog/item/123", "data-id": "123", "title": null},
+		"image": "https://example.com/img/1.png",
+		"lazy": "https://example.com/img/1-large.png",
+		"relative": "item/123",
+		"missing": null
+	}`, res.ToJson())
+}
+
+func (s *HTMLV2ParserArraySuite) Test_ResolveURLAfterRedirect() {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/old", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/shop/new/index.html", http.StatusFound)
+	})
+	mux.HandleFunc("/shop/new/index.html", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`<html><body><a href="../item/1">Item</a><span>/img/1.png</span></body></html>`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	res, err := parser.NewEngine(&config.ConnectorConfig{
+		ResponseType: config.HTML,
+		Url:          server.URL + "/old",
+		ServerConfig: &config.ServerConnectorConfig{
+			Method: http.MethodGet,
+		},
+	}, logger.Null).Get(&config.Model{
+		ObjectConfig: &config.ObjectConfig{
+			Fields: map[string]*config.Field{
+				"link": {
+					BaseField: &config.BaseField{
+						Type:       config.String,
+						Path:       "a",
+						Attribute:  "href",
+						ResolveURL: true,
+					},
+				},
+				"image": {
+					BaseField: &config.BaseField{
+						Type:       config.String,
+						Path:       "span",
+						ResolveURL: true,
+					},
+				},
+				"formatted": {
+					BaseField: &config.BaseField{
+						Type: config.String,
+						Path: "a",
+						Generated: &config.GeneratedFieldConfig{
+							Formatted: &config.FormattedFieldConfig{
+								Template: "{BASE_URL}#{PL}",
+							},
+						},
+					},
+				},
+				"calculated": {
+					BaseField: &config.BaseField{
+						Type:      config.String,
+						Path:      "a",
+						Attribute: "href",
+						Generated: &config.GeneratedFieldConfig{
+							Calculated: &config.CalculatedConfig{
+								Type:       config.String,
+								Expression: `resolveURL(fBaseURL, fRes)`,
+							},
+						},
+					},
+				},
+			},
+		},
+	}, nil, nil, nil)
+	require.NoError(s.T(), err)
+	assert.JSONEq(s.T(), `{
+		"link": "`+server.URL+`/shop/item/1",
+		"image": "`+server.URL+`/img/1.png",
+		"formatted": "`+server.URL+`/shop/new/index.html#Item",
+		"calculated": "`+server.URL+`/shop/item/1"
+	}`, res.ToJson())
+}
@@ -2,6 +2,7 @@ package parser_test
 
 import (
 	"encoding/json"
+	"github.com/PxyUp/fitter/pkg/builder"
 	"github.com/PxyUp/fitter/pkg/config"
 	"github.com/PxyUp/fitter/pkg/logger"
 	"github.com/PxyUp/fitter/pkg/parser"
@@ -9,6 +10,8 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"io"
+	"net/http"
+	"net/http/httptest"
 
 	"os"
 	"testing"
@@ -421,3 +424,49 @@ func (s *JsonV2ObjectSuite) Test_ParseNestedObject() {
 	assert.NoError(s.T(), err)
 	assert.JSONEq(s.T(), "{\"player_meal\": [{\"my_price\": 292},{\"my_price\": 357},{\"my_price\": 695}],\"player\": {\"latitude\": 44.823498,\"player_meal\": [{\"my_price\": 292},{\"my_price\": 357},{\"my_price\": 695}],\"name\": \"Henderson Gonzales\",\"isActive\": true,\"null\": null},\"tags\": [\"tempor\",\"magna\",\"ullamco\",\"Lorem\",\"sunt\",\"irure\",\"et\"]}\n", res.ToJson())
 }
+
+func (s *JsonV2ObjectSuite) Test_RequestBody() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content, _ := io.ReadAll(r.Body)
+		_ = json.NewEncoder(w).Encode(map[string]string{"result": string(content)})
+	}))
+	defer server.Close()
+
+	index := uint32(3)
+	res, err := parser.NewEngine(&config.ConnectorConfig{
+		ResponseType: config.Json,
+		Url:          server.URL,
+		ServerConfig: &config.ServerConnectorConfig{
+			Method: http.MethodPost,
+			JSON: &config.ObjectConfig{
+				Fields: map[string]*config.Field{
+					"query": {
+						BaseField: &config.BaseField{
+							Type: config.String,
+						},
+					},
+					"page": {
+						BaseField: &config.BaseField{
+							Type: config.String,
+							Generated: &config.GeneratedFieldConfig{
+								Formatted: &config.FormattedFieldConfig{
+									Template: "{HUMAN_INDEX}",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}, logger.Null).Get(&config.Model{
+		BaseField: &config.BaseField{
+			Type: config.RawString,
+			Path: "result",
+		},
+	}, builder.PureString("a&b=c"), &index, nil)
+	require.NoError(s.T(), err)
+
+	var actual string
+	require.NoError(s.T(), json.Unmarshal(res.Raw(), &actual))
+	assert.JSONEq(s.T(), `{"query":"a&b=c","page":"4"}`, actual)
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/PxyUp/fitter/pkg/builder"
 	"github.com/PxyUp/fitter/pkg/config"
 	"github.com/PxyUp/fitter/pkg/logger"
+	"github.com/PxyUp/fitter/pkg/utils"
 	"slices"
 	"strconv"
 	"sync"
@@ -13,22 +14,149 @@ func IsZero[T comparable](v T) bool {
 	return v == *new(T)
 }
 
+const (
+	textAttribute = "#text"
+)
+
+var (
+	// urlAttributes are resolved in Attributes field with ResolveURL
+	urlAttributes = map[string]bool{
+		"href":       true,
+		"src":        true,
+		"action":     true,
+		"formaction": true,
+		"poster":     true,
+		"cite":       true,
+		"data":       true,
+		"background": true,
+		"longdesc":   true,
+		"manifest":   true,
+	}
+)
+
 type engineParser[T comparable] struct {
-	parserBody T
-	getAll     func(T, string) []T
-	getOne     func(T, string) T
-	getText    func(T) string
+	parserBody   T
+	getAll       func(T, string) []T
+	getOne       func(T, string) T
+	getText      func(T) string
+	getAttribute func(T, string) (string, bool)
+	isEmpty      func(T) bool
+
+	pageURL  string
+	baseHref string
 
-	customFillUpBaseField func(T, *config.BaseField) builder.Interfacable
+	// unsupportedAttributes keep attributes which are already logged by parser without attributes
+	unsupportedAttributes sync.Map
+
+	customFillUpBaseField func(T, *config.BaseField, string) builder.Interfacable
 	logger                logger.Logger
 }
 
-func (e *engineParser[T]) fillUpBaseField(source T, field *config.BaseField) builder.Interfacable {
+// SetPageURL set url of the page which is used for resolve relative urls
+func (e *engineParser[T]) SetPageURL(pageURL string) {
+	e.pageURL = pageURL
+}
+
+func (e *engineParser[T]) baseURL() string {
+	if e.baseHref != "" {
+		return utils.ResolveURL(e.pageURL, e.baseHref)
+	}
+
+	return e.pageURL
+}
+
+func (e *engineParser[T]) resolveURL(value string) string {
+	return utils.ResolveURL(e.baseURL(), value)
+}
+
+func (e *engineParser[T]) isEmptySource(source T) bool {
 	if IsZero(source) {
+		return true
+	}
+
+	return e.isEmpty != nil && e.isEmpty(source)
+}
+
+func fieldAttribute(field *config.BaseField) string {
+	if field.Attribute != "" {
+		return field.Attribute
+	}
+
+	return field.HTMLAttribute
+}
+
+// attributeValue return value of the attribute, resolveURL resolve any attribute if onlyURLAttributes is false
+func (e *engineParser[T]) attributeValue(source T, attribute string, resolveURL bool, onlyURLAttributes bool) (string, bool) {
+	if attribute == textAttribute || e.getAttribute == nil {
+		if attribute != textAttribute {
+			if _, logged := e.unsupportedAttributes.LoadOrStore(attribute, struct{}{}); !logged {
+				e.logger.Errorw("attributes are not supported by parser, text is used", "attribute", attribute)
+			}
+		}
+
+		text := e.getText(source)
+		if resolveURL && !onlyURLAttributes {
+			return e.resolveURL(text), true
+		}
+		return text, true
+	}
+
+	value, ok := e.getAttribute(source, attribute)
+	if !ok {
+		return "", false
+	}
+
+	if resolveURL && (!onlyURLAttributes || urlAttributes[attribute]) {
+		return e.resolveURL(value), true
+	}
+
+	return value, true
+}
+
+func (e *engineParser[T]) fieldText(source T, field *config.BaseField) (string, bool) {
+	attribute := fieldAttribute(field)
+	if attribute == "" || field.Type == config.HtmlString {
+		if field.ResolveURL && field.Type != config.HtmlString {
+			return e.resolveURL(e.getText(source)), true
+		}
+		return e.getText(source), true
+	}
+
+	return e.attributeValue(source, attribute, field.ResolveURL, false)
+}
+
+func (e *engineParser[T]) buildAttributesField(source T, field *config.BaseField) builder.Interfacable {
+	if e.isEmptySource(source) {
+		return builder.NullValue
+	}
+
+	kv := make(map[string]builder.Interfacable, len(field.Attributes))
+	for _, attribute := range field.Attributes {
+		value, ok := e.attributeValue(source, attribute, field.ResolveURL, true)
+		if !ok {
+			kv[attribute] = builder.NullValue
+			continue
+		}
+		kv[attribute] = builder.String(value)
+	}
+
+	return builder.Object(kv)
+}
+
+func (e *engineParser[T]) fillUpBaseField(source T, field *config.BaseField) builder.Interfacable {
+	if e.isEmptySource(source) {
 		return builder.NullValue
 	}
 
-	text := e.getText(source)
+	text, ok := e.fieldText(source, field)
+	if !ok {
+		return builder.NullValue
+	}
+
+	if e.customFillUpBaseField != nil {
+		return e.customFillUpBaseField(source, field, text)
+	}
+
 	switch field.Type {
 	case config.Null:
 		return builder.NullValue
@@ -57,7 +185,7 @@ func (e *engineParser[T]) fillUpBaseField(source T, field *config.BaseField) bui
 	return builder.NullValue
 }
 
-func (e *engineParser[T]) buildObjectField(source T, objectConfig *config.ObjectConfig, input builder.Interfacable) builder.Interfacable {
+func (e *engineParser[T]) buildObjectField(source T, objectConfig *config.ObjectConfig, index *uint32, input builder.Interfacable) builder.Interfacable {
 	kv := make(map[string]builder.Interfacable)
 	var wg sync.WaitGroup
 	var mutex sync.Mutex
@@ -70,7 +198,7 @@ func (e *engineParser[T]) buildObjectField(source T, objectConfig *config.Object
 			defer wg.Done()
 
 			mutex.Lock()
-			kv[k] = e.resolveField(source, v, nil, input)
+			kv[k] = e.resolveField(source, v, index, input)
 			mutex.Unlock()
 
 		}(key, value)
@@ -114,14 +242,14 @@ func (e *engineParser[T]) buildBaseField(source T, field *config.BaseField, inde
 	}
 
 	var tempValue builder.Interfacable
-	if e.customFillUpBaseField != nil {
-		tempValue = e.customFillUpBaseField(source, field)
+	if len(field.Attributes) > 0 {
+		tempValue = e.buildAttributesField(source, field)
 	} else {
 		tempValue = e.fillUpBaseField(source, field)
 	}
 
 	if field.Generated != nil {
-		return buildGeneratedField(tempValue, field.Type, field.Generated, e.logger, index, input)
+		return buildGeneratedField(tempValue, field.Type, field.Generated, e.logger, index, input, e.baseURL())
 	}
 
 	return tempValue
@@ -137,7 +265,7 @@ func (e *engineParser[T]) resolveField(parent T, field *config.Field, index *uin
 	}
 
 	if field.ObjectConfig != nil {
-		return e.buildObjectField(parent, field.ObjectConfig, input)
+		return e.buildObjectField(parent, field.ObjectConfig, index, input)
 	}
 
 	if field.ArrayConfig != nil {
@@ -180,7 +308,7 @@ func (e *engineParser[T]) buildArray(array *config.ArrayConfig, input builder.In
 }
 
 func (e *engineParser[T]) buildObject(object *config.ObjectConfig, input builder.Interfacable) builder.Interfacable {
-	return e.buildObjectField(e.parserBody, object, input)
+	return e.buildObjectField(e.parserBody, object, nil, input)
 }
 
 func (e *engineParser[T]) Parse(model *config.Model, input builder.Interfacable) (*ParseResult, error) {
@@ -303,7 +431,7 @@ func FillArrayObjectField[T comparable](engine *engineParser[T], parent []T, siz
 		go func(index int, selection T) {
 			defer wg.Done()
 
-			values[index] = engine.buildObjectField(selection, cfg, input)
+			values[index] = engine.buildObjectField(selection, cfg, nil, input)
 		}(i, s)
 	}
 	wg.Wait()
@@ -26,6 +26,10 @@ var (
 	XMLFactory Factory = func(bytes []byte, logger logger.Logger) Parser {
 		return NewXML(bytes, logger.With("parser", "xml"))
 	}
+
+	TextFactory Factory = func(bytes []byte, logger logger.Logger) Parser {
+		return NewText(bytes, logger.With("parser", "text"))
+	}
 )
 
 type Factory func([]byte, logger.Logger) Parser
@@ -59,8 +63,8 @@ func (p *ParseResult) ToJson() string {
 	return p.Json
 }
 
-func getExpressionResult(expr string, fieldType config.FieldType, value builder.Interfacable, index *uint32, input builder.Interfacable, logger logger.Logger) builder.Interfacable {
-	res, err := utils.ProcessExpression(expr, value, index, input)
+func getExpressionResult(expr string, fieldType config.FieldType, value builder.Interfacable, index *uint32, input builder.Interfacable, logger logger.Logger, baseURL string) builder.Interfacable {
+	res, err := utils.ProcessExpressionWithBaseURL(expr, baseURL, value, index, input)
 	if err != nil {
 		logger.Errorw("error during process calculated field", "error", err.Error())
 		return builder.NullValue
@@ -69,7 +73,7 @@ func getExpressionResult(expr string, fieldType config.FieldType, value builder.
 	return res
 }
 
-func buildGeneratedField(parsedValue builder.Interfacable, fieldType config.FieldType, field *config.GeneratedFieldConfig, logger logger.Logger, index *uint32, input builder.Interfacable) builder.Interfacable {
+func buildGeneratedField(parsedValue builder.Interfacable, fieldType config.FieldType, field *config.GeneratedFieldConfig, logger logger.Logger, index *uint32, input builder.Interfacable, baseURL string) builder.Interfacable {
 	if fieldType == config.String {
 		parsedValue = builder.PureString(parsedValue.ToJson())
 	}
@@ -97,26 +101,26 @@ func buildGeneratedField(parsedValue builder.Interfacable, fieldType config.Fiel
 	}
 
 	if field.Calculated != nil && field.Calculated.Expression != "" {
-		return getExpressionResult(field.Calculated.Expression, field.Calculated.Type, parsedValue, index, input, logger)
+		return getExpressionResult(field.Calculated.Expression, field.Calculated.Type, parsedValue, index, input, logger, baseURL)
 	}
 
 	if field.Static != nil {
 		if len(field.Static.Raw) > 0 {
 			return builder.Static(&builder.StaticCfg{
 				Type:  field.Static.Type,
-				Value: utils.Format(string(field.Static.Raw), parsedValue, index, input),
+				Value: utils.Format(utils.FormatBaseURL(string(field.Static.Raw), baseURL), parsedValue, index, input),
 			})
 		}
 
 		return builder.Static(&builder.StaticCfg{
 			Type:  field.Static.Type,
-			Value: utils.Format(field.Static.Value, parsedValue, index, input),
+			Value: utils.Format(utils.FormatBaseURL(field.Static.Value, baseURL), parsedValue, index, input),
 		})
 
 	}
 
 	if field.Formatted != nil {
-		return builder.String(utils.Format(field.Formatted.Template, parsedValue, index, input), false)
+		return builder.String(utils.Format(utils.FormatBaseURL(field.Formatted.Template, baseURL), parsedValue, index, input), false)
 	}
 
 	if field.Plugin != nil {
@@ -133,7 +137,7 @@ func buildGeneratedField(parsedValue builder.Interfacable, fieldType config.Fiel
 		}
 
 		if field.Model.Expression != "" {
-			return getExpressionResult(field.Model.Expression, field.Model.Type, result, index, input, logger)
+			return getExpressionResult(field.Model.Expression, field.Model.Type, result, index, input, logger, baseURL)
 		}
 
 		if field.Model.Type == config.Array || field.Model.Type == config.Object {
@@ -178,3 +182,14 @@ func fillUpBaseField(source gjson.Result, field *config.BaseField) builder.Inter
 
 	return builder.EMPTY
 }
+
+// buildRequestObject build object for the request body, parsed value is used as json source for fields
+func buildRequestObject(cfg *config.ObjectConfig, parsedValue builder.Interfacable, index *uint32, input builder.Interfacable, logger logger.Logger) builder.Interfacable {
+	source := builder.NullValue.Raw()
+	if parsedValue != nil && !parsedValue.IsEmpty() {
+		source = parsedValue.Raw()
+	}
+
+	engine := NewJson(source, logger)
+	return engine.buildObjectField(engine.parserBody, cfg, index, input)
+}
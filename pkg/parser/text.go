@@ -0,0 +1,121 @@
+package parser
+
+import (
+	"github.com/PxyUp/fitter/pkg/logger"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+var (
+	textRegexpCache sync.Map
+)
+
+type textMatch struct {
+	value  string
+	groups map[string]string
+}
+
+func compileTextRegexp(expr string) (*regexp.Regexp, error) {
+	if cached, ok := textRegexpCache.Load(expr); ok {
+		return cached.(*regexp.Regexp), nil
+	}
+
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	textRegexpCache.Store(expr, re)
+	return re, nil
+}
+
+func newTextMatch(re *regexp.Regexp, source string, indexes []int, preferGroup bool) *textMatch {
+	match := &textMatch{
+		value:  source[indexes[0]:indexes[1]],
+		groups: make(map[string]string),
+	}
+
+	for i, name := range re.SubexpNames() {
+		if i == 0 || indexes[2*i] < 0 {
+			continue
+		}
+		if name != "" {
+			match.groups[name] = source[indexes[2*i]:indexes[2*i+1]]
+		}
+	}
+
+	if preferGroup && re.NumSubexp() > 0 && indexes[2] >= 0 {
+		match.value = source[indexes[2]:indexes[3]]
+	}
+
+	return match
+}
+
+func textLines(parent *textMatch) []*textMatch {
+	var lines []*textMatch
+	for _, line := range strings.Split(parent.value, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		lines = append(lines, &textMatch{
+			value: line,
+		})
+	}
+
+	return lines
+}
+
+func NewText(body []byte, logger logger.Logger) *engineParser[*textMatch] {
+	return &engineParser[*textMatch]{
+		getText: func(r *textMatch) string {
+			return r.value
+		},
+		parserBody: &textMatch{
+			value: string(body),
+		},
+		logger: logger,
+		getAll: func(parent *textMatch, expr string) []*textMatch {
+			if expr == "" {
+				return textLines(parent)
+			}
+
+			re, err := compileTextRegexp(expr)
+			if err != nil {
+				logger.Errorw("invalid regexp for text parser", "path", expr, "error", err.Error())
+				return nil
+			}
+
+			allIndexes := re.FindAllStringSubmatchIndex(parent.value, -1)
+			matches := make([]*textMatch, len(allIndexes))
+			for i, indexes := range allIndexes {
+				matches[i] = newTextMatch(re, parent.value, indexes, false)
+			}
+			return matches
+		},
+		getOne: func(parent *textMatch, expr string) *textMatch {
+			if expr == "" {
+				return parent
+			}
+
+			if group, ok := parent.groups[expr]; ok {
+				return &textMatch{
+					value: group,
+				}
+			}
+
+			re, err := compileTextRegexp(expr)
+			if err != nil {
+				logger.Errorw("invalid regexp for text parser", "path", expr, "error", err.Error())
+				return nil
+			}
+
+			indexes := re.FindStringSubmatchIndex(parent.value)
+			if indexes == nil {
+				return nil
+			}
+			return newTextMatch(re, parent.value, indexes, true)
+		},
+	}
+}
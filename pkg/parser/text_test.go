@@ -0,0 +1,159 @@
+package parser_test
+
+import (
+	"github.com/PxyUp/fitter/pkg/config"
+	"github.com/PxyUp/fitter/pkg/logger"
+	"github.com/PxyUp/fitter/pkg/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+const textBody = `status=ok
+uptime=3600
+[2024-05-01 10:00:01] ERROR disk full on /dev/sda1
+[2024-05-01 10:00:05] INFO backup started
+[2024-05-01 10:01:00] ERROR backup failed code=17
+`
+
+func TestNewText(t *testing.T) {
+	suite.Run(t, new(NewTextSuite))
+}
+
+type NewTextSuite struct {
+	suite.Suite
+	parser parser.Parser
+}
+
+func (s *NewTextSuite) SetupTest() {
+	s.parser = parser.NewText([]byte(textBody), logger.Null)
+}
+
+func (s *NewTextSuite) Test_BaseField_CaptureGroup() {
+	res, err := s.parser.Parse(&config.Model{
+		BaseField: &config.BaseField{
+			Type: config.Int,
+			Path: `uptime=(\d+)`,
+		},
+	}, nil)
+	assert.NoError(s.T(), err)
+	assert.JSONEq(s.T(), "3600", res.ToJson())
+}
+
+func (s *NewTextSuite) Test_BaseField_NoMatch() {
+	res, err := s.parser.Parse(&config.Model{
+		BaseField: &config.BaseField{
+			Type: config.String,
+			Path: `version=(\S+)`,
+		},
+	}, nil)
+	assert.NoError(s.T(), err)
+	assert.JSONEq(s.T(), "null", res.ToJson())
+}
+
+func (s *NewTextSuite) Test_Array_NamedGroups() {
+	res, err := s.parser.Parse(&config.Model{
+		ArrayConfig: &config.ArrayConfig{
+			RootPath: `\[(?P<time>[^\]]+)\] ERROR (?P<message>.+)`,
+			ItemConfig: &config.ObjectConfig{
+				Fields: map[string]*config.Field{
+					"time": {
+						BaseField: &config.BaseField{
+							Type: config.String,
+							Path: "time",
+						},
+					},
+					"message": {
+						BaseField: &config.BaseField{
+							Type: config.String,
+							Path: "message",
+						},
+					},
+					"code": {
+						BaseField: &config.BaseField{
+							Type: config.Int,
+							Path: `code=(\d+)`,
+						},
+					},
+				},
+			},
+		},
+	}, nil)
+	assert.NoError(s.T(), err)
+	assert.JSONEq(s.T(), `[
+		{"time": "2024-05-01 10:00:01", "message": "disk full on /dev/sda1", "code": null},
+		{"time": "2024-05-01 10:01:00", "message": "backup failed code=17", "code": 17}
+	]`, res.ToJson())
+}
+
+func (s *NewTextSuite) Test_Array_Lines() {
+	res, err := s.parser.Parse(&config.Model{
+		ArrayConfig: &config.ArrayConfig{
+			LengthLimit: 2,
+			ItemConfig: &config.ObjectConfig{
+				Field: &config.BaseField{
+					Type: config.String,
+					Path: `=(.+)$`,
+				},
+			},
+		},
+	}, nil)
+	assert.NoError(s.T(), err)
+	assert.JSONEq(s.T(), `["ok", "3600"]`, res.ToJson())
+}
+
+func (s *NewTextSuite) Test_Object_Generated() {
+	res, err := s.parser.Parse(&config.Model{
+		ObjectConfig: &config.ObjectConfig{
+			Fields: map[string]*config.Field{
+				"status": {
+					BaseField: &config.BaseField{
+						Type: config.String,
+						Path: `status=(\w+)`,
+						Generated: &config.GeneratedFieldConfig{
+							Formatted: &config.FormattedFieldConfig{
+								Template: "service is {PL}",
+							},
+						},
+					},
+				},
+			},
+		},
+	}, nil)
+	assert.NoError(s.T(), err)
+	assert.JSONEq(s.T(), `{"status": "service is ok"}`, res.ToJson())
+}
+
+type errorsLogger struct {
+	logger.Logger
+	errors []string
+}
+
+func (l *errorsLogger) With(...string) logger.Logger {
+	return l
+}
+
+func (l *errorsLogger) Errorw(msg string, _ ...string) {
+	l.errors = append(l.errors, msg)
+}
+
+func (s *NewTextSuite) Test_UnsupportedAttribute_LoggedPerParser() {
+	model := &config.Model{
+		BaseField: &config.BaseField{
+			Type:      config.String,
+			Path:      `status=(\S+)`,
+			Attribute: "href",
+		},
+	}
+
+	for range 2 {
+		log := &errorsLogger{Logger: logger.Null}
+		textParser := parser.NewText([]byte(textBody), log)
+		for range 2 {
+			res, err := textParser.Parse(model, nil)
+			assert.NoError(s.T(), err)
+			assert.JSONEq(s.T(), `"ok"`, res.ToJson())
+		}
+		assert.Equal(s.T(), []string{"attributes are not supported by parser, text is used"}, log.errors)
+	}
+}
@@ -13,6 +13,14 @@ func NewXML(body []byte, logger logger.Logger) *engineParser[*xmlquery.Node] {
 		getText: func(node *xmlquery.Node) string {
 			return node.InnerText()
 		},
+		getAttribute: func(node *xmlquery.Node, attribute string) (string, bool) {
+			for _, attr := range node.Attr {
+				if attr.Name.Local == attribute || (attr.Name.Space != "" && attr.Name.Space+":"+attr.Name.Local == attribute) {
+					return attr.Value, true
+				}
+			}
+			return "", false
+		},
 		parserBody: document,
 		logger:     logger,
 		getAll: func(top *xmlquery.Node, expr string) []*xmlquery.Node {
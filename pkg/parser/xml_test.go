@@ -189,3 +189,28 @@ func (s *NewXMLSuite) Test_ReturnSimpleArrayOfArray_Index_Reverse() {
 	assert.NoError(s.T(), err)
 	assert.JSONEq(s.T(), "{\"menu\": [[\"7 0\",\"1 1\"],[\"6 0\",\"1 1\"],[\"5 0\",\"1 1\"],[\"3 0\",\"1 1\"],[\"4 0\",\"1 1\"]]}\n", res.ToJson())
 }
+
+func (s *NewXMLSuite) Test_Attributes() {
+	res, err := parser.NewXML([]byte(`<?xml version="1.0"?><feed><entry id="42" lang="en"><title>Hello</title></entry></feed>`), logger.Null).Parse(&config.Model{
+		ArrayConfig: &config.ArrayConfig{
+			RootPath: "/feed/entry",
+			ItemConfig: &config.ObjectConfig{
+				Fields: map[string]*config.Field{
+					"id": {
+						BaseField: &config.BaseField{
+							Type:      config.Int,
+							Attribute: "id",
+						},
+					},
+					"meta": {
+						BaseField: &config.BaseField{
+							Attributes: []string{"lang", "#text"},
+						},
+					},
+				},
+			},
+		},
+	}, nil)
+	assert.NoError(s.T(), err)
+	assert.JSONEq(s.T(), `[{"id": 42, "meta": {"lang": "en", "#text": "Hello"}}]`, res.ToJson())
+}
@@ -10,9 +10,25 @@ import (
 func NewXPath(body []byte, logger logger.Logger) *engineParser[*html.Node] {
 	document, _ := htmlquery.Parse(bytes.NewReader(body))
 
+	var baseHref string
+	if document != nil {
+		if base := htmlquery.FindOne(document, "//base[@href]"); base != nil {
+			baseHref = htmlquery.SelectAttr(base, "href")
+		}
+	}
+
 	return &engineParser[*html.Node]{
-		getText:    htmlquery.InnerText,
+		getText: htmlquery.InnerText,
+		getAttribute: func(node *html.Node, attribute string) (string, bool) {
+			for _, attr := range node.Attr {
+				if attr.Key == attribute {
+					return attr.Val, true
+				}
+			}
+			return "", false
+		},
 		parserBody: document,
+		baseHref:   baseHref,
 		logger:     logger,
 		getAll: func(top *html.Node, expr string) []*html.Node {
 			nodes, err := htmlquery.QueryAll(top, expr)
@@ -381,3 +381,30 @@ func (s *XPathV2Suite) Test_ParseNestedObject() {
 	assert.NoError(s.T(), err)
 	assert.JSONEq(s.T(), "{\"player\": {\"latitude\": 3.120000,\"player_meal\": [{\"my_price\": \"first\"},{\"my_price\": \"second\"},{\"my_price\": null},{\"my_price\": null},{\"my_price\": null},{\"my_price\": null},{\"my_price\": null},{\"my_price\": null},{\"my_price\": null}],\"name\": \"HTML Headings\",\"isActive\": true,\"null\": null}}\n", res.ToJson())
 }
+
+func (s *XPathV2Suite) Test_Attributes() {
+	p := parser.NewXPath([]byte(`<html><body><a href="/item/1" data-id="1">Item</a></body></html>`), logger.Null)
+	p.SetPageURL("https://example.com/list?page=2")
+	res, err := p.Parse(&config.Model{
+		ObjectConfig: &config.ObjectConfig{
+			Fields: map[string]*config.Field{
+				"id": {
+					BaseField: &config.BaseField{
+						Type:      config.Int,
+						Path:      "//a",
+						Attribute: "data-id",
+					},
+				},
+				"link": {
+					BaseField: &config.BaseField{
+						Path:       "//a",
+						Attributes: []string{"#text", "href"},
+						ResolveURL: true,
+					},
+				},
+			},
+		},
+	}, nil)
+	assert.NoError(s.T(), err)
+	assert.JSONEq(s.T(), `{"id": 1, "link": {"#text": "Item", "href": "https://example.com/item/1"}}`, res.ToJson())
+}
@@ -1,7 +1,9 @@
 package processor
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"github.com/PxyUp/fitter/pkg/builder"
 	"github.com/PxyUp/fitter/pkg/config"
 	"github.com/PxyUp/fitter/pkg/logger"
@@ -18,6 +20,8 @@ var (
 
 type Processor interface {
 	Process(input builder.Interfacable) (*parser.ParseResult, error)
+	// Shutdown send buffered notifications of the processor and stop its background workers
+	Shutdown(ctx context.Context)
 }
 
 type processor struct {
@@ -47,6 +51,8 @@ func (n *nullProcessor) Process(input builder.Interfacable) (*parser.ParseResult
 	return nil, n.err
 }
 
+func (n *nullProcessor) Shutdown(ctx context.Context) {}
+
 func New(name string, engine parser.Engine, model *config.Model, notifier notifier.Notifier, notifierCfg *config.NotifierConfig) *processor {
 	return &processor{
 		name:        name,
@@ -72,7 +78,7 @@ func (p *processor) Process(input builder.Interfacable) (*parser.ParseResult, er
 		}
 
 		if p.notifierCfg != nil {
-			if p.notifierCfg.Template != "" {
+			if p.notifierCfg.Template != "" && p.notifierCfg.TemplateMode == "" {
 				strValue := builder.ToJsonableFromString(utils.Format(p.notifierCfg.Template, result, nil, nil))
 				result = &parser.ParseResult{
 					RawResult: strValue.Raw(),
@@ -89,7 +95,8 @@ func (p *processor) Process(input builder.Interfacable) (*parser.ParseResult, er
 				return result, nil
 			}
 		}
-		errNot := notifier.Inform(p.notifier, p.name, result, err, isArray && p.notifierCfg.SendArrayByItem && !result.IsEmpty(), p.notifier.GetLogger(), input)
+		byItem := p.notifierCfg.SendArrayByItem || p.notifierCfg.Batch != nil || p.notifierCfg.Digest != nil
+		errNot := notifier.Inform(p.notifier, p.name, result, err, isArray && byItem && !result.IsEmpty(), p.notifier.GetLogger(), input)
 		if errNot != nil {
 			p.logger.Errorw("cannot notify about result", "error", errNot.Error())
 		}
@@ -102,6 +109,12 @@ func (p *processor) Process(input builder.Interfacable) (*parser.ParseResult, er
 	return result, nil
 }
 
+func (p *processor) Shutdown(ctx context.Context) {
+	if p.notifier != nil {
+		notifier.Close(ctx, p.notifier)
+	}
+}
+
 func CreateProcessor(item *config.Item, refMap config.RefMap, logger logger.Logger) Processor {
 	if item.Name == "" {
 		return Null(errMissingName, nil)
@@ -111,6 +124,18 @@ func CreateProcessor(item *config.Item, refMap config.RefMap, logger logger.Logg
 		return parser.NewEngine(model.ConnectorConfig, logger.With("reference_name", refName)).Get(model.Model, nil, nil, nil)
 	})
 
+	notifierInstance, err := createNotifier(item, logger)
+	if err != nil {
+		return Null(err)
+	}
+
+	logger = logger.With("name", item.Name)
+
+	return New(item.Name, parser.NewEngine(item.ConnectorConfig, logger.With("component", "processor_engine")), item.Model, notifierInstance, item.NotifierConfig).WithLogger(logger)
+}
+
+// createNotifier return notifier for the item, notifier is wrapped into template, delivery and grouping settings
+func createNotifier(item *config.Item, logger logger.Logger) (notifier.Notifier, error) {
 	var notifierInstance notifier.Notifier
 
 	if item.NotifierConfig != nil {
@@ -133,9 +158,86 @@ func CreateProcessor(item *config.Item, refMap config.RefMap, logger logger.Logg
 		if item.NotifierConfig.File != nil {
 			notifierInstance = notifier.NewFile(item.Name, item.NotifierConfig.File).WithLogger(logger.With("notifier", "file"))
 		}
+
+		if item.NotifierConfig.SQL != nil {
+			notifierInstance = notifier.NewSQL(item.Name, item.NotifierConfig.SQL).WithLogger(logger.With("notifier", "sql"))
+		}
+
+		if item.NotifierConfig.RedisStream != nil {
+			notifierInstance = notifier.NewRedisStream(item.Name, item.NotifierConfig.RedisStream).WithLogger(logger.With("notifier", "redis_stream"))
+		}
+
+		if item.NotifierConfig.NATS != nil {
+			notifierInstance = notifier.NewNATS(item.Name, item.NotifierConfig.NATS).WithLogger(logger.With("notifier", "nats"))
+		}
+
+		if item.NotifierConfig.Kafka != nil {
+			notifierInstance = notifier.NewKafka(item.Name, item.NotifierConfig.Kafka).WithLogger(logger.With("notifier", "kafka"))
+		}
+
+		if item.NotifierConfig.AMQP != nil {
+			notifierInstance = notifier.NewAMQP(item.Name, item.NotifierConfig.AMQP).WithLogger(logger.With("notifier", "amqp"))
+		}
+
+		if item.NotifierConfig.Slack != nil {
+			notifierInstance = notifier.NewSlack(item.Name, item.NotifierConfig.Slack).WithLogger(logger.With("notifier", "slack"))
+		}
+
+		if item.NotifierConfig.Discord != nil {
+			notifierInstance = notifier.NewDiscord(item.Name, item.NotifierConfig.Discord).WithLogger(logger.With("notifier", "discord"))
+		}
+
+		if item.NotifierConfig.Matrix != nil {
+			notifierInstance = notifier.NewMatrix(item.Name, item.NotifierConfig.Matrix).WithLogger(logger.With("notifier", "matrix"))
+		}
+
+		if item.NotifierConfig.SMTP != nil {
+			notifierInstance = notifier.NewSMTP(item.Name, item.NotifierConfig.SMTP).WithLogger(logger.With("notifier", "smtp"))
+		}
+
+		if notifierInstance != nil && item.NotifierConfig.Template != "" && item.NotifierConfig.TemplateMode != "" {
+			templateNotifier, err := notifier.WithTemplate(notifierInstance, item.Name, item.NotifierConfig.Template, item.NotifierConfig.TemplateMode)
+			if err != nil {
+				logger.Errorw("unable to parse notifier template", "name", item.Name, "error", err.Error())
+				return nil, err
+			}
+			notifierInstance = templateNotifier
+		}
+
+		if notifierInstance != nil && item.NotifierConfig.Delivery != nil {
+			notifierInstance = notifier.WithDelivery(notifierInstance, item.Name, item.NotifierConfig.Delivery)
+		}
+
+		if notifierInstance != nil && item.NotifierConfig.Digest != nil {
+			notifierInstance = notifier.WithDigest(notifierInstance, item.Name, item.NotifierConfig.Digest)
+		} else if notifierInstance != nil && item.NotifierConfig.Batch != nil {
+			notifierInstance = notifier.WithBatch(notifierInstance, item.Name, item.NotifierConfig.Batch)
+		}
 	}
 
-	logger = logger.With("name", item.Name)
+	return notifierInstance, nil
+}
 
-	return New(item.Name, parser.NewEngine(item.ConnectorConfig, logger.With("component", "processor_engine")), item.Model, notifierInstance, item.NotifierConfig).WithLogger(logger)
+// ReplayDeadLetters send again notifications which are stored in dead letter of the item
+func ReplayDeadLetters(item *config.Item, logger logger.Logger) error {
+	if item.NotifierConfig == nil || item.NotifierConfig.Delivery == nil || item.NotifierConfig.Delivery.DeadLetter == "" {
+		return nil
+	}
+
+	notifierInstance, err := createNotifier(item, logger)
+	if err != nil {
+		return err
+	}
+	if notifierInstance == nil {
+		return nil
+	}
+
+	delivered, failed, err := notifier.Replay(notifierInstance)
+	if err != nil {
+		logger.Errorw("unable to replay dead letter", "name", item.Name, "error", err.Error())
+		return err
+	}
+
+	logger.Infow("dead letter replayed", "name", item.Name, "delivered", fmt.Sprintf("%d", delivered), "failed", fmt.Sprintf("%d", failed))
+	return nil
 }
@@ -0,0 +1,183 @@
+package proxy
+
+import (
+	"errors"
+	"github.com/PxyUp/fitter/pkg/config"
+	"math/rand"
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	defaultMaxFailures = 3
+	defaultCooldown    = 60 * time.Second
+)
+
+var (
+	errNoHealthyProxy = errors.New("no healthy proxy in pool")
+
+	defaultBanStatusCodes = []int{403, 429}
+
+	pools = make(map[string]*Pool)
+	mutex sync.RWMutex
+)
+
+// Proxy is proxy picked from the pool, result of the request with it must be reported back
+type Proxy struct {
+	*config.ProxyConfig
+
+	index int
+}
+
+type proxyState struct {
+	failures       uint32
+	unhealthyUntil time.Time
+}
+
+type Pool struct {
+	cfg            *config.ProxyPoolConfig
+	maxFailures    uint32
+	cooldown       time.Duration
+	banStatusCodes []int
+
+	mutex  sync.Mutex
+	next   int
+	states []*proxyState
+	sticky map[string]int
+}
+
+// SetPools create proxy pools from config, already created pools stay untouched
+func SetPools(cfg config.ProxyPools) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	for name, poolCfg := range cfg {
+		if _, ok := pools[name]; ok || poolCfg == nil {
+			continue
+		}
+		pools[name] = NewPool(poolCfg)
+	}
+}
+
+// Get return pool by name or nil if pool not exists
+func Get(name string) *Pool {
+	mutex.RLock()
+	defer mutex.RUnlock()
+
+	return pools[name]
+}
+
+func NewPool(cfg *config.ProxyPoolConfig) *Pool {
+	pool := &Pool{
+		cfg:            cfg,
+		maxFailures:    defaultMaxFailures,
+		cooldown:       defaultCooldown,
+		banStatusCodes: defaultBanStatusCodes,
+		states:         make([]*proxyState, len(cfg.Proxies)),
+		sticky:         make(map[string]int),
+	}
+
+	if cfg.MaxFailures > 0 {
+		pool.maxFailures = cfg.MaxFailures
+	}
+	if cfg.Cooldown > 0 {
+		pool.cooldown = time.Duration(cfg.Cooldown) * time.Second
+	}
+	if len(cfg.BanStatusCodes) > 0 {
+		pool.banStatusCodes = cfg.BanStatusCodes
+	}
+	for i := range pool.states {
+		pool.states[i] = &proxyState{}
+	}
+
+	return pool
+}
+
+func (p *Pool) healthy(index int, now time.Time) bool {
+	return !p.states[index].unhealthyUntil.After(now)
+}
+
+func (p *Pool) roundRobin(now time.Time) (int, bool) {
+	for i := 0; i < len(p.states); i++ {
+		index := (p.next + i) % len(p.states)
+		if p.healthy(index, now) {
+			p.next = index + 1
+			return index, true
+		}
+	}
+	return 0, false
+}
+
+func (p *Pool) random(now time.Time) (int, bool) {
+	var candidates []int
+	for i := range p.states {
+		if p.healthy(i, now) {
+			candidates = append(candidates, i)
+		}
+	}
+	if len(candidates) == 0 {
+		return 0, false
+	}
+	return candidates[rand.Intn(len(candidates))], true
+}
+
+// Pick select healthy proxy for the host according to strategy of the pool
+func (p *Pool) Pick(host string) (*Proxy, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	now := time.Now()
+	var index int
+	var ok bool
+	switch p.cfg.Strategy {
+	case config.Random:
+		index, ok = p.random(now)
+	case config.Sticky:
+		if index, ok = p.sticky[host]; ok && p.healthy(index, now) {
+			break
+		}
+		if index, ok = p.roundRobin(now); ok {
+			p.sticky[host] = index
+		}
+	default:
+		index, ok = p.roundRobin(now)
+	}
+
+	if !ok {
+		return nil, errNoHealthyProxy
+	}
+
+	return &Proxy{
+		ProxyConfig: p.cfg.Proxies[index],
+		index:       index,
+	}, nil
+}
+
+// Report update health of the proxy with result of the request, statusCode is ignored if err is not nil
+func (p *Pool) Report(proxy *Proxy, statusCode int, err error) {
+	if proxy == nil {
+		return
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	state := p.states[proxy.index]
+	if err == nil && slices.Contains(p.banStatusCodes, statusCode) {
+		state.failures = 0
+		state.unhealthyUntil = time.Now().Add(p.cooldown)
+		return
+	}
+
+	if err == nil {
+		state.failures = 0
+		return
+	}
+
+	state.failures++
+	if state.failures >= p.maxFailures {
+		state.failures = 0
+		state.unhealthyUntil = time.Now().Add(p.cooldown)
+	}
+}
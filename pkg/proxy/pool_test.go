@@ -0,0 +1,82 @@
+package proxy_test
+
+import (
+	"errors"
+	"github.com/PxyUp/fitter/pkg/config"
+	"github.com/PxyUp/fitter/pkg/proxy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+var (
+	proxies = []*config.ProxyConfig{
+		{Server: "http://first:8080"},
+		{Server: "http://second:8080"},
+		{Server: "http://third:8080"},
+	}
+	errNetwork = errors.New("network error")
+)
+
+func pick(t *testing.T, pool *proxy.Pool, host string) *proxy.Proxy {
+	picked, err := pool.Pick(host)
+	require.NoError(t, err)
+	return picked
+}
+
+func TestRoundRobinWithHealth(t *testing.T) {
+	pool := proxy.NewPool(&config.ProxyPoolConfig{
+		Proxies:     proxies,
+		MaxFailures: 2,
+	})
+
+	first := pick(t, pool, "example.com")
+	second := pick(t, pool, "example.com")
+	third := pick(t, pool, "example.com")
+	assert.Equal(t, "http://first:8080", first.Server)
+	assert.Equal(t, "http://second:8080", second.Server)
+	assert.Equal(t, "http://third:8080", third.Server)
+
+	pool.Report(second, 429, nil)
+	pool.Report(third, 0, errNetwork)
+	assert.Equal(t, "http://first:8080", pick(t, pool, "example.com").Server)
+	assert.Equal(t, "http://third:8080", pick(t, pool, "example.com").Server)
+
+	pool.Report(third, 0, errNetwork)
+	pool.Report(first, 403, nil)
+	_, err := pool.Pick("example.com")
+	assert.Error(t, err)
+}
+
+func TestSticky(t *testing.T) {
+	pool := proxy.NewPool(&config.ProxyPoolConfig{
+		Proxies:  proxies,
+		Strategy: config.Sticky,
+	})
+
+	first := pick(t, pool, "first.com")
+	second := pick(t, pool, "second.com")
+	assert.NotEqual(t, first.Server, second.Server)
+	assert.Equal(t, first.Server, pick(t, pool, "first.com").Server)
+	assert.Equal(t, second.Server, pick(t, pool, "second.com").Server)
+
+	pool.Report(first, 200, nil)
+	assert.Equal(t, first.Server, pick(t, pool, "first.com").Server)
+
+	pool.Report(first, 429, nil)
+	assert.NotEqual(t, first.Server, pick(t, pool, "first.com").Server)
+}
+
+func TestRandom(t *testing.T) {
+	pool := proxy.NewPool(&config.ProxyPoolConfig{
+		Proxies:        proxies[:2],
+		Strategy:       config.Random,
+		BanStatusCodes: []int{503},
+	})
+
+	first := pick(t, pool, "example.com")
+	pool.Report(first, 503, nil)
+	for i := 0; i < 10; i++ {
+		assert.NotEqual(t, first.Server, pick(t, pool, "example.com").Server)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/PxyUp/fitter/pkg/limitter"
 	"github.com/PxyUp/fitter/pkg/logger"
 	"github.com/PxyUp/fitter/pkg/processor"
+	"github.com/PxyUp/fitter/pkg/session"
 )
 
 var (
@@ -24,6 +25,7 @@ type localRegistry struct {
 func NewFromConfig(config *config.Config, logger logger.Logger) *localRegistry {
 	if config != nil {
 		limitter.SetLimits(config.Limits)
+		session.SetSessions(config.Sessions, logger.With("component", "session"))
 	}
 
 	kv := make(map[string]processor.Processor)
@@ -40,6 +42,7 @@ func NewFromConfig(config *config.Config, logger logger.Logger) *localRegistry {
 
 func FromItem(itemCfg *config.CliItem, logger logger.Logger) *localRegistry {
 	limitter.SetLimits(itemCfg.Limits)
+	session.SetSessions(itemCfg.Sessions, logger.With("component", "session"))
 
 	return &localRegistry{
 		logger: logger,
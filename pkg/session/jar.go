@@ -0,0 +1,293 @@
+package session
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"github.com/PxyUp/fitter/pkg/config"
+	"github.com/PxyUp/fitter/pkg/logger"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	httpOnlyPrefix = "#HttpOnly_"
+	netscapeHeader = "# Netscape HTTP Cookie File\n"
+
+	persistExpiryPrecision = time.Minute
+)
+
+type cookieEntry struct {
+	domain            string
+	includeSubdomains bool
+	path              string
+	secure            bool
+	httpOnly          bool
+	expires           time.Time
+	name              string
+	value             string
+}
+
+func (c *cookieEntry) key() string {
+	return c.domain + ";" + c.path + ";" + c.name
+}
+
+// same compare entries, expiration less than persistExpiryPrecision is ignored, so cookie with Max-Age does not rewrite file on each response
+func (c *cookieEntry) same(other *cookieEntry) bool {
+	if other == nil {
+		return false
+	}
+
+	diff := c.expires.Sub(other.expires)
+	if diff < 0 {
+		diff = -diff
+	}
+
+	return c.value == other.value && c.includeSubdomains == other.includeSubdomains && c.secure == other.secure &&
+		c.httpOnly == other.httpOnly && c.expires.IsZero() == other.expires.IsZero() && diff < persistExpiryPrecision
+}
+
+func (c *cookieEntry) expired(now time.Time) bool {
+	return !c.expires.IsZero() && c.expires.Before(now)
+}
+
+// jar is cookiejar.Jar which remember all cookies for store them on disk
+type jar struct {
+	original http.CookieJar
+	cfg      *config.SessionConfig
+	logger   logger.Logger
+
+	mutex   sync.Mutex
+	cookies map[string]*cookieEntry
+
+	persistMutex sync.Mutex
+}
+
+func (j *jar) Cookies(u *url.URL) []*http.Cookie {
+	return j.original.Cookies(u)
+}
+
+func (j *jar) SetCookies(u *url.URL, cookies []*http.Cookie) {
+	j.original.SetCookies(u, cookies)
+
+	now := time.Now()
+	changed := false
+	j.mutex.Lock()
+	for _, cookie := range cookies {
+		entry := toEntry(u, cookie, now)
+		if entry.expired(now) || cookie.MaxAge < 0 {
+			if _, ok := j.cookies[entry.key()]; ok {
+				delete(j.cookies, entry.key())
+				changed = true
+			}
+			continue
+		}
+		// cookie rejected by jar(like cookie for another domain) is not stored, otherwise it is accepted after restart
+		if !j.accepted(entry) {
+			continue
+		}
+		if !entry.same(j.cookies[entry.key()]) {
+			changed = true
+		}
+		j.cookies[entry.key()] = entry
+	}
+	j.mutex.Unlock()
+
+	if changed && j.cfg.PersistPath != "" {
+		if err := j.persist(j.cfg.PersistPath); err != nil {
+			j.logger.Errorw("unable to persist session cookies", "path", j.cfg.PersistPath, "error", err.Error())
+		}
+	}
+}
+
+// accepted check that jar keeps cookie of the entry
+func (j *jar) accepted(entry *cookieEntry) bool {
+	for _, cookie := range j.original.Cookies(&url.URL{Scheme: "https", Host: strings.TrimPrefix(entry.domain, "."), Path: entry.path}) {
+		if cookie.Name == entry.name && cookie.Value == entry.value {
+			return true
+		}
+	}
+	return false
+}
+
+func defaultPath(u *url.URL) string {
+	p := u.EscapedPath()
+	if p == "" || p[0] != '/' {
+		return "/"
+	}
+
+	i := strings.LastIndex(p, "/")
+	if i == 0 {
+		return "/"
+	}
+	return p[:i]
+}
+
+func toEntry(u *url.URL, cookie *http.Cookie, now time.Time) *cookieEntry {
+	entry := &cookieEntry{
+		domain:   u.Hostname(),
+		path:     cookie.Path,
+		secure:   cookie.Secure,
+		httpOnly: cookie.HttpOnly,
+		name:     cookie.Name,
+		value:    cookie.Value,
+	}
+
+	if cookie.Domain != "" {
+		entry.domain = "." + strings.TrimPrefix(strings.ToLower(cookie.Domain), ".")
+		entry.includeSubdomains = true
+	}
+
+	if entry.path == "" || entry.path[0] != '/' {
+		entry.path = defaultPath(u)
+	}
+
+	if cookie.MaxAge > 0 {
+		entry.expires = now.Add(time.Duration(cookie.MaxAge) * time.Second)
+	} else if !cookie.Expires.IsZero() {
+		entry.expires = cookie.Expires
+	}
+
+	return entry
+}
+
+func (j *jar) load(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	now := time.Now()
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		entry, ok := parseNetscapeLine(scanner.Text())
+		if !ok || entry.expired(now) {
+			continue
+		}
+
+		scheme := "http"
+		if entry.secure {
+			scheme = "https"
+		}
+		cookie := &http.Cookie{
+			Name:     entry.name,
+			Value:    entry.value,
+			Path:     entry.path,
+			Secure:   entry.secure,
+			HttpOnly: entry.httpOnly,
+			Expires:  entry.expires,
+		}
+		if entry.includeSubdomains {
+			cookie.Domain = entry.domain
+		}
+		j.original.SetCookies(&url.URL{Scheme: scheme, Host: strings.TrimPrefix(entry.domain, "."), Path: entry.path}, []*http.Cookie{cookie})
+		if !j.accepted(entry) {
+			continue
+		}
+
+		j.mutex.Lock()
+		j.cookies[entry.key()] = entry
+		j.mutex.Unlock()
+	}
+
+	return scanner.Err()
+}
+
+func parseNetscapeLine(line string) (*cookieEntry, bool) {
+	httpOnly := false
+	if strings.HasPrefix(line, httpOnlyPrefix) {
+		httpOnly = true
+		line = strings.TrimPrefix(line, httpOnlyPrefix)
+	}
+
+	line = strings.TrimRight(line, "\r")
+	if line == "" || strings.HasPrefix(line, "#") {
+		return nil, false
+	}
+
+	parts := strings.Split(line, "\t")
+	if len(parts) != 7 {
+		return nil, false
+	}
+
+	entry := &cookieEntry{
+		domain:            strings.ToLower(parts[0]),
+		includeSubdomains: strings.EqualFold(parts[1], "TRUE"),
+		path:              parts[2],
+		secure:            strings.EqualFold(parts[3], "TRUE"),
+		httpOnly:          httpOnly,
+		name:              parts[5],
+		value:             parts[6],
+	}
+
+	if expires, err := strconv.ParseInt(parts[4], 10, 64); err == nil && expires > 0 {
+		entry.expires = time.Unix(expires, 0)
+	}
+
+	if entry.includeSubdomains && !strings.HasPrefix(entry.domain, ".") {
+		entry.domain = "." + entry.domain
+	}
+
+	return entry, true
+}
+
+func boolFlag(value bool) string {
+	if value {
+		return "TRUE"
+	}
+	return "FALSE"
+}
+
+func (j *jar) persist(path string) error {
+	j.persistMutex.Lock()
+	defer j.persistMutex.Unlock()
+
+	now := time.Now()
+
+	j.mutex.Lock()
+	entries := make([]*cookieEntry, 0, len(j.cookies))
+	for _, entry := range j.cookies {
+		if entry.expired(now) {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	j.mutex.Unlock()
+
+	sort.Slice(entries, func(i, k int) bool {
+		return entries[i].key() < entries[k].key()
+	})
+
+	var buf bytes.Buffer
+	buf.WriteString(netscapeHeader)
+	for _, entry := range entries {
+		domain := entry.domain
+		if entry.httpOnly {
+			domain = httpOnlyPrefix + domain
+		}
+		var expires int64
+		if !entry.expires.IsZero() {
+			expires = entry.expires.Unix()
+		}
+		_, _ = fmt.Fprintf(&buf, "%s\t%s\t%s\t%s\t%d\t%s\t%s\n", domain, boolFlag(entry.includeSubdomains), entry.path, boolFlag(entry.secure), expires, entry.name, entry.value)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	tmpFile := path + ".tmp"
+	if err := os.WriteFile(tmpFile, buf.Bytes(), 0600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpFile, path)
+}
@@ -0,0 +1,74 @@
+package session
+
+import (
+	"github.com/PxyUp/fitter/pkg/config"
+	"github.com/PxyUp/fitter/pkg/logger"
+	"net/http"
+	"net/http/cookiejar"
+	"sync"
+)
+
+var (
+	sessions = make(map[string]*jar)
+	mutex    sync.RWMutex
+)
+
+// SetSessions create named sessions from config, already created sessions stay untouched
+func SetSessions(cfg config.SessionMap, logger logger.Logger) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	for name, sessionCfg := range cfg {
+		if _, ok := sessions[name]; ok || sessionCfg == nil {
+			continue
+		}
+
+		sessionJar, err := newJar(sessionCfg, logger.With("session", name))
+		if err != nil {
+			logger.Errorw("unable to create session", "session", name, "error", err.Error())
+			continue
+		}
+		sessions[name] = sessionJar
+	}
+}
+
+// Jar return cookie jar of the session by name or nil if session not exists
+func Jar(name string) http.CookieJar {
+	mutex.RLock()
+	defer mutex.RUnlock()
+
+	if sessionJar, ok := sessions[name]; ok {
+		return sessionJar
+	}
+
+	return nil
+}
+
+func newJar(cfg *config.SessionConfig, logger logger.Logger) (*jar, error) {
+	original, err := cookiejar.New(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	sessionJar := &jar{
+		original: original,
+		cookies:  make(map[string]*cookieEntry),
+		cfg:      cfg,
+		logger:   logger,
+	}
+
+	if cfg.CookieFile != "" {
+		if err = sessionJar.load(cfg.CookieFile); err != nil {
+			logger.Errorw("unable to load cookie file", "path", cfg.CookieFile, "error", err.Error())
+			return nil, err
+		}
+	}
+
+	if cfg.PersistPath != "" {
+		if err = sessionJar.load(cfg.PersistPath); err != nil {
+			logger.Infow("unable to load persisted cookies, session will start empty", "path", cfg.PersistPath, "error", err.Error())
+		}
+	}
+
+	return sessionJar, nil
+}
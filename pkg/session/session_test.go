@@ -0,0 +1,107 @@
+package session_test
+
+import (
+	"github.com/PxyUp/fitter/pkg/config"
+	"github.com/PxyUp/fitter/pkg/logger"
+	"github.com/PxyUp/fitter/pkg/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"testing"
+)
+
+func TestSession(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/login" {
+			http.SetCookie(w, &http.Cookie{Name: "sid", Value: "secret", Path: "/"})
+			return
+		}
+		sid, err := r.Cookie("sid")
+		if err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		seed, _ := r.Cookie("seed")
+		_, _ = w.Write([]byte(sid.Value + " " + seed.Value))
+	}))
+	defer server.Close()
+
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	tmpDir := t.TempDir()
+	cookieFile := path.Join(tmpDir, "cookies.txt")
+	persistPath := path.Join(tmpDir, "persist", "session.txt")
+	require.NoError(t, os.WriteFile(cookieFile, []byte("# Netscape HTTP Cookie File\n"+serverURL.Hostname()+"\tFALSE\t/\tFALSE\t0\tseed\tfrom_file\n"), 0600))
+
+	session.SetSessions(config.SessionMap{
+		"first": {
+			CookieFile:  cookieFile,
+			PersistPath: persistPath,
+		},
+	}, logger.Null)
+
+	client := &http.Client{Jar: session.Jar("first")}
+	_, err = client.Get(server.URL + "/login")
+	require.NoError(t, err)
+
+	resp, err := client.Get(server.URL + "/data")
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "secret from_file", string(body))
+
+	persisted, err := os.ReadFile(persistPath)
+	require.NoError(t, err)
+	assert.True(t, strings.Contains(string(persisted), "\tsid\tsecret"))
+
+	session.SetSessions(config.SessionMap{
+		"second": {
+			PersistPath: persistPath,
+		},
+	}, logger.Null)
+	assert.Len(t, session.Jar("second").Cookies(serverURL), 2)
+	assert.Nil(t, session.Jar("unknown"))
+}
+
+func TestSession_PersistAccepted(t *testing.T) {
+	persistPath := path.Join(t.TempDir(), "session.txt")
+	session.SetSessions(config.SessionMap{
+		"accepted": {
+			PersistPath: persistPath,
+		},
+	}, logger.Null)
+	jar := session.Jar("accepted")
+
+	requestURL, err := url.Parse("http://a.com/login")
+	require.NoError(t, err)
+	jar.SetCookies(requestURL, []*http.Cookie{
+		{Name: "sid", Value: "secret", Path: "/"},
+		{Name: "planted", Value: "evil", Domain: "b.com", Path: "/"},
+	})
+
+	persisted, err := os.ReadFile(persistPath)
+	require.NoError(t, err)
+	assert.True(t, strings.Contains(string(persisted), "\tsid\tsecret"))
+	assert.False(t, strings.Contains(string(persisted), "planted"))
+
+	require.NoError(t, os.Remove(persistPath))
+	jar.SetCookies(requestURL, []*http.Cookie{
+		{Name: "sid", Value: "secret", Path: "/"},
+	})
+	_, err = os.Stat(persistPath)
+	assert.True(t, os.IsNotExist(err))
+
+	jar.SetCookies(requestURL, []*http.Cookie{
+		{Name: "sid", Value: "rotated", Path: "/"},
+	})
+	persisted, err = os.ReadFile(persistPath)
+	require.NoError(t, err)
+	assert.True(t, strings.Contains(string(persisted), "\tsid\trotated"))
+}
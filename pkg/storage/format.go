@@ -0,0 +1,271 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/PxyUp/fitter/pkg/builder"
+	"github.com/PxyUp/fitter/pkg/config"
+	"github.com/PxyUp/fitter/pkg/logger"
+	"github.com/PxyUp/fitter/pkg/utils"
+	"github.com/parquet-go/parquet-go"
+	"github.com/tidwall/gjson"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	ErrUnknownFormat  = errors.New("unknown file format")
+	ErrMissingColumns = errors.New("missing columns for file format")
+
+	formatContentTypes = map[config.FileFormat]string{
+		config.JSONLFormat:   "application/x-ndjson",
+		config.CSVFormat:     "text/csv",
+		config.ParquetFormat: "application/vnd.apache.parquet",
+	}
+
+	// fileMutex protect choice of the file and its header(csv header, parquet append, size rotation)
+	fileMutex sync.Mutex
+)
+
+// WriteRecords write json rows into file with format of the config. Return path of the file or s3://bucket/key for S3
+func WriteRecords(cfg *config.FileStorageField, destinationFileName string, destinationPath string, rows []json.RawMessage, contentType string, input builder.Interfacable, logger logger.Logger) (string, error) {
+	if _, ok := formatContentTypes[cfg.Format]; !ok {
+		return "", fmt.Errorf("%w: %s", ErrUnknownFormat, cfg.Format)
+	}
+	if cfg.Format != config.JSONLFormat && len(cfg.Columns) == 0 {
+		return "", fmt.Errorf("%w: %s", ErrMissingColumns, cfg.Format)
+	}
+
+	fileName := intervalFileName(destinationFileName, cfg.Rotation, time.Now().UTC())
+
+	if cfg.S3 != nil {
+		if cfg.Append {
+			return "", ErrS3Append
+		}
+		content, err := encodeRows(cfg, rows, true)
+		if err != nil {
+			return "", err
+		}
+		if contentType == "" {
+			contentType = formatContentTypes[cfg.Format]
+		}
+		return Upload(cfg.S3, Key(destinationPath, fileName), content, contentType, cfg.Gzip, input, logger)
+	}
+
+	fileMutex.Lock()
+	defer fileMutex.Unlock()
+
+	if cfg.Append && cfg.Format == config.ParquetFormat {
+		// parquet can not be appended, rows are written into next free file instead of rewriting existing one
+		fileName = indexedFileName(destinationPath, fileName, func(stat os.FileInfo, err error) bool {
+			return err != nil || stat.Size() == 0
+		})
+	} else {
+		fileName = sizeFileName(destinationPath, fileName, cfg.Rotation)
+	}
+	stat, errStat := os.Stat(path.Join(destinationPath, fileName))
+	exists := errStat == nil && stat.Size() > 0
+
+	content, err := encodeRows(cfg, rows, !cfg.Append || !exists)
+	if err != nil {
+		return "", err
+	}
+
+	if cfg.Append && cfg.Format != config.ParquetFormat {
+		return utils.CreateFileWithContent(content, fileName, destinationPath, os.ModePerm, true, logger)
+	}
+
+	return utils.WriteFileAtomic(content, fileName, destinationPath, os.ModePerm, logger)
+}
+
+func encodeRows(cfg *config.FileStorageField, rows []json.RawMessage, header bool) ([]byte, error) {
+	switch cfg.Format {
+	case config.JSONLFormat:
+		return encodeJSONL(rows), nil
+	case config.CSVFormat:
+		return encodeCSV(cfg.Columns, rows, header)
+	case config.ParquetFormat:
+		return encodeParquet(cfg.Columns, rows)
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnknownFormat, cfg.Format)
+}
+
+func encodeJSONL(rows []json.RawMessage) []byte {
+	var buf bytes.Buffer
+	for _, row := range rows {
+		if err := json.Compact(&buf, row); err != nil {
+			// not json content(for example from template) is written as is
+			buf.WriteString(strings.TrimRight(string(row), "\n"))
+		}
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes()
+}
+
+func encodeCSV(columns []*config.FileColumn, rows []json.RawMessage, header bool) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+
+	record := make([]string, len(columns))
+	if header {
+		for i, column := range columns {
+			record[i] = column.Name
+		}
+		if err := writer.Write(record); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, row := range rows {
+		for i, column := range columns {
+			record[i] = columnString(columnValue(row, column.Path))
+		}
+		if err := writer.Write(record); err != nil {
+			return nil, err
+		}
+	}
+
+	writer.Flush()
+	return buf.Bytes(), writer.Error()
+}
+
+func parquetSchema(columns []*config.FileColumn) *parquet.Schema {
+	group := make(parquet.Group, len(columns))
+	for _, column := range columns {
+		var node parquet.Node
+		switch column.Type {
+		case config.IntColumn:
+			node = parquet.Int(64)
+		case config.FloatColumn:
+			node = parquet.Leaf(parquet.DoubleType)
+		case config.BoolColumn:
+			node = parquet.Leaf(parquet.BooleanType)
+		default:
+			node = parquet.String()
+		}
+		group[column.Name] = parquet.Optional(node)
+	}
+	return parquet.NewSchema("record", group)
+}
+
+func encodeParquet(columns []*config.FileColumn, rows []json.RawMessage) ([]byte, error) {
+	schema := parquetSchema(columns)
+
+	parquetRows := make([]parquet.Row, 0, len(rows))
+	for _, row := range rows {
+		parquetRow := make(parquet.Row, 0, len(columns))
+		for _, column := range columns {
+			leaf, _ := schema.Lookup(column.Name)
+			value := columnValue(row, column.Path)
+			definitionLevel := 0
+			if value.Exists() && value.Type != gjson.Null {
+				definitionLevel = 1
+			}
+			parquetRow = append(parquetRow, parquetValue(value, column.Type).Level(0, definitionLevel, leaf.ColumnIndex))
+		}
+		// values of the row must be ordered like columns of the schema
+		sort.Slice(parquetRow, func(i, j int) bool {
+			return parquetRow[i].Column() < parquetRow[j].Column()
+		})
+		parquetRows = append(parquetRows, parquetRow)
+	}
+
+	var buf bytes.Buffer
+	writer := parquet.NewWriter(&buf, schema, parquet.Compression(&parquet.Snappy))
+	if _, err := writer.WriteRows(parquetRows); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func columnValue(row json.RawMessage, jsonPath string) gjson.Result {
+	if jsonPath == "" {
+		return gjson.ParseBytes(row)
+	}
+	return gjson.GetBytes(row, jsonPath)
+}
+
+func columnString(value gjson.Result) string {
+	if value.IsObject() || value.IsArray() {
+		return value.Raw
+	}
+	return value.String()
+}
+
+func parquetValue(value gjson.Result, columnType config.FileColumnType) parquet.Value {
+	if !value.Exists() || value.Type == gjson.Null {
+		return parquet.NullValue()
+	}
+
+	switch columnType {
+	case config.IntColumn:
+		return parquet.Int64Value(value.Int())
+	case config.FloatColumn:
+		return parquet.DoubleValue(value.Float())
+	case config.BoolColumn:
+		return parquet.BooleanValue(value.Bool())
+	}
+	return parquet.ByteArrayValue([]byte(columnString(value)))
+}
+
+// intervalFileName add date of the rotation interval before extension: results.jsonl -> results-2024-05-01.jsonl
+func intervalFileName(fileName string, rotation *config.FileRotation, now time.Time) string {
+	if rotation == nil || fileName == "" {
+		return fileName
+	}
+
+	layout := ""
+	switch rotation.Interval {
+	case config.DayRotation:
+		layout = "2006-01-02"
+	case config.HourRotation:
+		layout = "2006-01-02-15"
+	default:
+		return fileName
+	}
+
+	ext := filepath.Ext(fileName)
+	return strings.TrimSuffix(fileName, ext) + "-" + now.Format(layout) + ext
+}
+
+// sizeFileName return first file name with index which is smaller than MaxSize: results.jsonl, results.1.jsonl, results.2.jsonl...
+func sizeFileName(destinationPath string, fileName string, rotation *config.FileRotation) string {
+	if rotation == nil || rotation.MaxSize <= 0 {
+		return fileName
+	}
+
+	return indexedFileName(destinationPath, fileName, func(stat os.FileInfo, err error) bool {
+		return err != nil || stat.Size() < rotation.MaxSize
+	})
+}
+
+// indexedFileName return first file name(starting from fileName) with index which fits: results.jsonl, results.1.jsonl, results.2.jsonl...
+func indexedFileName(destinationPath string, fileName string, fits func(stat os.FileInfo, err error) bool) string {
+	if fileName == "" {
+		return fileName
+	}
+
+	ext := filepath.Ext(fileName)
+	base := strings.TrimSuffix(fileName, ext)
+	for i := 0; ; i++ {
+		candidate := fileName
+		if i > 0 {
+			candidate = base + "." + strconv.Itoa(i) + ext
+		}
+		if fits(os.Stat(path.Join(destinationPath, candidate))) {
+			return candidate
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package storage_test
+
+import (
+	"encoding/json"
+	"github.com/PxyUp/fitter/pkg/config"
+	"github.com/PxyUp/fitter/pkg/logger"
+	"github.com/PxyUp/fitter/pkg/storage"
+	"github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path"
+	"testing"
+	"time"
+)
+
+func rows(raws ...string) []json.RawMessage {
+	result := make([]json.RawMessage, len(raws))
+	for i, raw := range raws {
+		result[i] = json.RawMessage(raw)
+	}
+	return result
+}
+
+func TestWriteRecordsJSONL(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &config.FileStorageField{
+		Format: config.JSONLFormat,
+		Append: true,
+		Rotation: &config.FileRotation{
+			Interval: config.DayRotation,
+			MaxSize:  30,
+		},
+	}
+
+	filePath, err := storage.WriteRecords(cfg, "results.jsonl", dir, rows(`{"id": 1,
+		"title": "first"}`, `{"id": 2}`), "", nil, logger.Null)
+	require.NoError(t, err)
+	assert.Equal(t, path.Join(dir, "results-"+time.Now().UTC().Format("2006-01-02")+".jsonl"), filePath)
+
+	filePath2, err := storage.WriteRecords(cfg, "results.jsonl", dir, rows(`{"id": 3}`), "", nil, logger.Null)
+	require.NoError(t, err)
+	assert.Equal(t, path.Join(dir, "results-"+time.Now().UTC().Format("2006-01-02")+".1.jsonl"), filePath2)
+
+	content, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, "{\"id\":1,\"title\":\"first\"}\n{\"id\":2}\n", string(content))
+
+	content, err = os.ReadFile(filePath2)
+	require.NoError(t, err)
+	assert.Equal(t, "{\"id\":3}\n", string(content))
+}
+
+func TestWriteRecordsCSV(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &config.FileStorageField{
+		Format: config.CSVFormat,
+		Append: true,
+		Columns: []*config.FileColumn{
+			{Name: "id", Path: "id"},
+			{Name: "title", Path: "title"},
+			{Name: "tags", Path: "tags"},
+		},
+	}
+
+	_, err := storage.WriteRecords(cfg, "results.csv", dir, rows(`{"id": 1, "title": "Hello, world", "tags": ["a"]}`), "", nil, logger.Null)
+	require.NoError(t, err)
+	filePath, err := storage.WriteRecords(cfg, "results.csv", dir, rows(`{"id": 2}`), "", nil, logger.Null)
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, "id,title,tags\n1,\"Hello, world\",\"[\"\"a\"\"]\"\n2,,\n", string(content))
+
+	_, err = storage.WriteRecords(&config.FileStorageField{Format: config.CSVFormat}, "results.csv", dir, rows(`{}`), "", nil, logger.Null)
+	assert.ErrorIs(t, err, storage.ErrMissingColumns)
+	_, err = storage.WriteRecords(&config.FileStorageField{Format: "xml"}, "results.xml", dir, rows(`{}`), "", nil, logger.Null)
+	assert.ErrorIs(t, err, storage.ErrUnknownFormat)
+}
+
+type parquetRecord struct {
+	Id    *int64   `parquet:"id,optional"`
+	Price *float64 `parquet:"price,optional"`
+	Title *string  `parquet:"title,optional"`
+	Sale  *bool    `parquet:"sale,optional"`
+}
+
+func TestWriteRecordsParquet(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &config.FileStorageField{
+		Format: config.ParquetFormat,
+		Append: true,
+		Columns: []*config.FileColumn{
+			{Name: "title", Path: "title"},
+			{Name: "id", Path: "id", Type: config.IntColumn},
+			{Name: "price", Path: "price.value", Type: config.FloatColumn},
+			{Name: "sale", Path: "sale", Type: config.BoolColumn},
+		},
+	}
+
+	firstPath, err := storage.WriteRecords(cfg, "results.parquet", dir, rows(`{"id": 1, "title": "first", "price": {"value": 10.5}, "sale": true}`, `{"id": 2, "title": null}`), "", nil, logger.Null)
+	require.NoError(t, err)
+	assert.Equal(t, path.Join(dir, "results.parquet"), firstPath)
+
+	records, err := parquet.ReadFile[parquetRecord](firstPath)
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+
+	assert.Equal(t, int64(1), *records[0].Id)
+	assert.Equal(t, 10.5, *records[0].Price)
+	assert.Equal(t, "first", *records[0].Title)
+	assert.True(t, *records[0].Sale)
+
+	assert.Equal(t, int64(2), *records[1].Id)
+	assert.Nil(t, records[1].Price)
+	assert.Nil(t, records[1].Title)
+	assert.Nil(t, records[1].Sale)
+
+	// appended rows are written into next file, so schema can be changed without reading of existing file
+	cfg.Columns = cfg.Columns[:2]
+	secondPath, err := storage.WriteRecords(cfg, "results.parquet", dir, rows(`{"id": 3, "title": "third"}`), "", nil, logger.Null)
+	require.NoError(t, err)
+	assert.Equal(t, path.Join(dir, "results.1.parquet"), secondPath)
+
+	records, err = parquet.ReadFile[parquetRecord](secondPath)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, int64(3), *records[0].Id)
+	assert.Equal(t, "third", *records[0].Title)
+
+	records, err = parquet.ReadFile[parquetRecord](firstPath)
+	require.NoError(t, err)
+	assert.Len(t, records, 2)
+}
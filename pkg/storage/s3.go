@@ -0,0 +1,195 @@
+package storage
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/PxyUp/fitter/pkg/builder"
+	"github.com/PxyUp/fitter/pkg/config"
+	"github.com/PxyUp/fitter/pkg/http_client"
+	"github.com/PxyUp/fitter/pkg/logger"
+	"github.com/PxyUp/fitter/pkg/utils"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"time"
+)
+
+const (
+	defaultRegion       = "us-east-1"
+	defaultContentType  = "application/octet-stream"
+	uploadTimeout       = 10 * time.Minute
+	s3Service           = "s3"
+	gzipContentEncoding = "gzip"
+)
+
+var (
+	ErrMissingBucket = errors.New("missing bucket of the s3 storage")
+	ErrS3Request     = errors.New("s3 request failed")
+	ErrS3Append      = errors.New("append is not supported for s3 storage")
+)
+
+// Upload put content into bucket, content is gzip compressed if needed. Return s3://bucket/key of the object
+func Upload(cfg *config.S3StorageConfig, key string, content []byte, contentType string, gzipContent bool, input builder.Interfacable, logger logger.Logger) (string, error) {
+	if gzipContent {
+		var compressed bytes.Buffer
+		writer := gzip.NewWriter(&compressed)
+		if _, err := writer.Write(content); err != nil {
+			return "", err
+		}
+		if err := writer.Close(); err != nil {
+			return "", err
+		}
+		content = compressed.Bytes()
+	}
+
+	return putObject(cfg, key, bytes.NewReader(content), int64(len(content)), hashHex(content), contentType, gzipContent, input, logger)
+}
+
+// UploadFromReader spool reader into temporary file and put it into bucket, so content is not buffered in memory
+func UploadFromReader(cfg *config.S3StorageConfig, key string, reader io.Reader, contentType string, gzipContent bool, limit int64, input builder.Interfacable, logger logger.Logger) (string, error) {
+	tmpFile, err := os.CreateTemp("", "fitter-s3-*")
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = tmpFile.Close()
+		_ = os.Remove(tmpFile.Name())
+	}()
+
+	hash := sha256.New()
+	var writer io.Writer = io.MultiWriter(tmpFile, hash)
+	var gzipWriter *gzip.Writer
+	if gzipContent {
+		gzipWriter = gzip.NewWriter(writer)
+		writer = gzipWriter
+	}
+
+	if _, err = io.Copy(utils.LimitWriter(writer, limit), reader); err != nil {
+		logger.Errorw("unable to read content for upload", "key", key, "error", err.Error())
+		return "", err
+	}
+	if gzipWriter != nil {
+		if err = gzipWriter.Close(); err != nil {
+			return "", err
+		}
+	}
+
+	size, err := tmpFile.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return "", err
+	}
+	if _, err = tmpFile.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+
+	return putObject(cfg, key, tmpFile, size, hex.EncodeToString(hash.Sum(nil)), contentType, gzipContent, input, logger)
+}
+
+// Key join path and file name into key of the object
+func Key(destinationPath string, fileName string) string {
+	return strings.TrimPrefix(path.Join(destinationPath, fileName), "/")
+}
+
+func putObject(cfg *config.S3StorageConfig, key string, body io.Reader, size int64, payloadHash string, contentType string, gzipContent bool, input builder.Interfacable, logger logger.Logger) (string, error) {
+	bucket := utils.Format(cfg.Bucket, nil, nil, input)
+	if bucket == "" {
+		return "", ErrMissingBucket
+	}
+	if key == "" {
+		return "", utils.ErrMissingFileName
+	}
+
+	region := formatOrEnv(cfg.Region, input, "AWS_REGION")
+	if region == "" {
+		region = defaultRegion
+	}
+
+	objectURL, err := objectURL(cfg, region, bucket, key, input)
+	if err != nil {
+		return "", err
+	}
+
+	if contentType == "" {
+		contentType = mime.TypeByExtension(path.Ext(key))
+	}
+	if contentType == "" {
+		contentType = defaultContentType
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), uploadTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, objectURL.String(), body)
+	if err != nil {
+		return "", err
+	}
+	req.ContentLength = size
+	req.Header.Set("Content-Type", contentType)
+	if gzipContent {
+		req.Header.Set("Content-Encoding", gzipContentEncoding)
+	}
+
+	credentials := &Credentials{
+		AccessKeyId:     formatOrEnv(cfg.AccessKeyId, input, "AWS_ACCESS_KEY_ID"),
+		SecretAccessKey: formatOrEnv(cfg.SecretAccessKey, input, "AWS_SECRET_ACCESS_KEY"),
+		SessionToken:    formatOrEnv(cfg.SessionToken, input, "AWS_SESSION_TOKEN"),
+	}
+	if credentials.AccessKeyId != "" {
+		SignV4(req, payloadHash, credentials, region, s3Service, time.Now())
+	}
+
+	logger.Infow("uploading object", "bucket", bucket, "key", key, "size", fmt.Sprintf("%d", size))
+	resp, err := http_client.GetDefaultClient().Do(req)
+	if err != nil {
+		logger.Errorw("unable to upload object", "bucket", bucket, "key", key, "error", err.Error())
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		errResp := fmt.Errorf("%w: %d %s", ErrS3Request, resp.StatusCode, string(respBody))
+		logger.Errorw("unable to upload object", "bucket", bucket, "key", key, "error", errResp.Error())
+		return "", errResp
+	}
+
+	return "s3://" + bucket + "/" + key, nil
+}
+
+func objectURL(cfg *config.S3StorageConfig, region string, bucket string, key string, input builder.Interfacable) (*url.URL, error) {
+	endpoint := utils.Format(cfg.Endpoint, nil, nil, input)
+	if endpoint == "" {
+		endpoint = "https://s3." + region + ".amazonaws.com"
+	}
+
+	objectURL, err := url.Parse(strings.TrimSuffix(endpoint, "/"))
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.PathStyle {
+		objectURL.Path += "/" + bucket + "/" + key
+	} else {
+		objectURL.Host = bucket + "." + objectURL.Host
+		objectURL.Path += "/" + key
+	}
+	objectURL.RawPath = canonicalURI(objectURL.Path)
+
+	return objectURL, nil
+}
+
+func formatOrEnv(value string, input builder.Interfacable, env string) string {
+	if value != "" {
+		return utils.Format(value, nil, nil, input)
+	}
+	return os.Getenv(env)
+}
@@ -0,0 +1,117 @@
+package stream
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/PxyUp/fitter/pkg/http_client"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	defaultSSEEvent = "message"
+)
+
+var (
+	errUnexpectedStatus = errors.New("unexpected status code")
+)
+
+type sse struct {
+	body   io.ReadCloser
+	reader *bufio.Reader
+	event  string
+	cancel context.CancelFunc
+}
+
+// DialSSE connect to Server-Sent Events endpoint, only events with provided name are returned("message" by default)
+func DialSSE(ctx context.Context, url string, headers map[string]string, event string) (Stream, error) {
+	streamCtx, cancel := context.WithCancel(ctx)
+	req, err := http.NewRequestWithContext(streamCtx, http.MethodGet, url, nil)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("Cache-Control", "no-cache")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	transport, err := http_client.GetTransport(nil, nil, nil)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	// client without timeout, stream is alive until context is canceled or stream is closed
+	resp, err := (&http.Client{Transport: transport}).Do(req)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		_ = resp.Body.Close()
+		cancel()
+		return nil, fmt.Errorf("%w: %d", errUnexpectedStatus, resp.StatusCode)
+	}
+
+	if event == "" {
+		event = defaultSSEEvent
+	}
+
+	return &sse{
+		body:   resp.Body,
+		reader: bufio.NewReader(resp.Body),
+		event:  event,
+		cancel: cancel,
+	}, nil
+}
+
+// Next return data of the next event, multiline data joined with new line
+func (s *sse) Next() ([]byte, error) {
+	event := defaultSSEEvent
+	var data []string
+	hasData := false
+
+	for {
+		line, err := s.reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		line = strings.TrimRight(line, "\r\n")
+
+		if line == "" {
+			if hasData && event == s.event {
+				return []byte(strings.Join(data, "\n")), nil
+			}
+			event = defaultSSEEvent
+			data = nil
+			hasData = false
+			continue
+		}
+
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "event":
+			event = value
+		case "data":
+			data = append(data, value)
+			hasData = true
+		}
+	}
+}
+
+func (s *sse) Close() error {
+	s.cancel()
+	return s.body.Close()
+}
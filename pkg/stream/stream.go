@@ -0,0 +1,72 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/PxyUp/fitter/pkg/builder"
+	"io"
+	"time"
+)
+
+// Stream is connection which push messages from server
+type Stream interface {
+	// Next block until next message comes, return io.EOF when stream is ended by server
+	Next() ([]byte, error)
+	Close() error
+}
+
+// ToValue return json value of the message if message is valid json, otherwise message as string
+func ToValue(msg []byte) builder.Interfacable {
+	if json.Valid(msg) {
+		return builder.ToJsonable(msg)
+	}
+
+	return builder.String(string(msg), false)
+}
+
+// Collect read messages until maxMessages received, window is over or stream is ended, stream is closed after
+func Collect(s Stream, maxMessages uint32, window time.Duration) ([]builder.Interfacable, error) {
+	defer s.Close()
+
+	messages := make(chan []byte)
+	errs := make(chan error, 1)
+	done := make(chan struct{})
+	defer close(done)
+
+	go func() {
+		for {
+			msg, err := s.Next()
+			if err != nil {
+				errs <- err
+				return
+			}
+
+			select {
+			case messages <- msg:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	timer := time.NewTimer(window)
+	defer timer.Stop()
+
+	result := make([]builder.Interfacable, 0)
+	for maxMessages == 0 || uint32(len(result)) < maxMessages {
+		select {
+		case msg := <-messages:
+			result = append(result, ToValue(msg))
+		case err := <-errs:
+			if errors.Is(err, io.EOF) || errors.Is(err, context.DeadlineExceeded) || len(result) > 0 {
+				return result, nil
+			}
+			return nil, err
+		case <-timer.C:
+			return result, nil
+		}
+	}
+
+	return result, nil
+}
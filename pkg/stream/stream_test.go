@@ -0,0 +1,71 @@
+package stream_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/PxyUp/fitter/pkg/builder"
+	"github.com/PxyUp/fitter/pkg/stream"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/websocket"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func toJson(values []builder.Interfacable) string {
+	return builder.Array(values).ToJson()
+}
+
+func TestWebSocket(t *testing.T) {
+	server := httptest.NewServer(websocket.Handler(func(conn *websocket.Conn) {
+		var subscribe string
+		if err := websocket.Message.Receive(conn, &subscribe); err != nil {
+			return
+		}
+		for i := 0; i < 5; i++ {
+			_ = websocket.Message.Send(conn, fmt.Sprintf(`{"channel": "%s", "price": %d}`, subscribe, i))
+		}
+		_ = websocket.Message.Send(conn, "plain text")
+		time.Sleep(time.Second)
+	}))
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+
+	s, err := stream.DialWebSocket(context.Background(), wsURL, nil, "btc")
+	require.NoError(t, err)
+	messages, err := stream.Collect(s, 2, time.Second)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"channel": "btc", "price": 0}, {"channel": "btc", "price": 1}]`, toJson(messages))
+
+	s, err = stream.DialWebSocket(context.Background(), wsURL, nil, "eth")
+	require.NoError(t, err)
+	messages, err = stream.Collect(s, 0, 300*time.Millisecond)
+	require.NoError(t, err)
+	assert.Len(t, messages, 6)
+	assert.Equal(t, `"plain text"`, messages[5].ToJson())
+}
+
+func TestSSE(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "text/event-stream", r.Header.Get("Accept"))
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = w.Write([]byte(": comment\n\ndata: {\"price\": 1}\n\nevent: ping\ndata: skip\n\nevent: trade\ndata: {\"price\":\ndata: 2}\n\ndata: {\"price\": 3}\r\n\r\n"))
+	}))
+	defer server.Close()
+
+	s, err := stream.DialSSE(context.Background(), server.URL, nil, "")
+	require.NoError(t, err)
+	messages, err := stream.Collect(s, 0, time.Second)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"price": 1}, {"price": 3}]`, toJson(messages))
+
+	s, err = stream.DialSSE(context.Background(), server.URL, nil, "trade")
+	require.NoError(t, err)
+	messages, err = stream.Collect(s, 1, time.Second)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"price": 2}]`, toJson(messages))
+}
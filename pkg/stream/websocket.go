@@ -0,0 +1,70 @@
+package stream
+
+import (
+	"context"
+	"golang.org/x/net/websocket"
+	"net/url"
+)
+
+type webSocket struct {
+	conn *websocket.Conn
+}
+
+// DialWebSocket connect to websocket server and send subscribe message if it is not empty
+func DialWebSocket(ctx context.Context, rawURL string, headers map[string]string, subscribe string) (Stream, error) {
+	origin, err := originOf(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := websocket.NewConfig(rawURL, origin)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range headers {
+		cfg.Header.Set(k, v)
+	}
+
+	conn, err := cfg.DialContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if subscribe != "" {
+		if err = websocket.Message.Send(conn, subscribe); err != nil {
+			_ = conn.Close()
+			return nil, err
+		}
+	}
+
+	return &webSocket{
+		conn: conn,
+	}, nil
+}
+
+func (w *webSocket) Next() ([]byte, error) {
+	var msg []byte
+	if err := websocket.Message.Receive(w.conn, &msg); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+func (w *webSocket) Close() error {
+	return w.conn.Close()
+}
+
+// originOf build http origin from websocket url, origin header is required by handshake
+func originOf(rawURL string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+
+	scheme := "http"
+	if u.Scheme == "wss" || u.Scheme == "https" {
+		scheme = "https"
+	}
+
+	return scheme + "://" + u.Host, nil
+}
@@ -0,0 +1,118 @@
+package trigger
+
+import (
+	"context"
+	"github.com/PxyUp/fitter/pkg/logger"
+	"github.com/PxyUp/fitter/pkg/stream"
+	"time"
+)
+
+const (
+	defaultReconnect = 5 * time.Second
+)
+
+type dialFn func(ctx context.Context) (stream.Stream, error)
+
+type streamTrigger struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	parentCtx context.Context
+
+	dial      dialFn
+	reconnect time.Duration
+	logger    logger.Logger
+	name      string
+}
+
+// Stream fire item for each message from stream, connection is restored after reconnect delay
+func Stream(parentCtx context.Context, name string, dial dialFn, reconnect uint32) *streamTrigger {
+	reconnectDelay := defaultReconnect
+	if reconnect > 0 {
+		reconnectDelay = time.Duration(reconnect) * time.Second
+	}
+
+	return &streamTrigger{
+		name:      name,
+		dial:      dial,
+		reconnect: reconnectDelay,
+		parentCtx: parentCtx,
+		logger:    logger.Null,
+	}
+}
+
+func (s *streamTrigger) WithLogger(logger logger.Logger) *streamTrigger {
+	s.logger = logger
+	return s
+}
+
+func (s *streamTrigger) Run(updates chan<- *Message) {
+	if s.ctx != nil {
+		return
+	}
+	localCtx, cancelFn := context.WithCancel(s.parentCtx)
+
+	s.ctx = localCtx
+	s.cancel = cancelFn
+
+	go func() {
+		for {
+			s.listen(localCtx, updates)
+
+			select {
+			case <-localCtx.Done():
+				s.logger.Infof("stop stream trigger %s", s.name)
+				return
+			case <-time.After(s.reconnect):
+				s.logger.Infof("reconnect stream trigger %s", s.name)
+			}
+		}
+	}()
+}
+
+func (s *streamTrigger) listen(ctx context.Context, updates chan<- *Message) {
+	connCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	conn, err := s.dial(connCtx)
+	if err != nil {
+		s.logger.Errorw("unable to connect to stream", "error", err.Error())
+		return
+	}
+
+	// close connection on stop of the trigger, otherwise Next is blocked forever
+	go func() {
+		<-connCtx.Done()
+		_ = conn.Close()
+	}()
+
+	for {
+		msg, errNext := conn.Next()
+		if errNext != nil {
+			if ctx.Err() == nil {
+				s.logger.Errorw("stream is closed", "error", errNext.Error())
+			}
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case updates <- &Message{
+			Name:  s.name,
+			Value: stream.ToValue(msg),
+		}:
+			s.logger.Infof("send stream trigger for %s", s.name)
+		}
+	}
+}
+
+func (s *streamTrigger) Stop() {
+	if s.ctx == nil {
+		return
+	}
+
+	s.cancel()
+	s.ctx = nil
+	s.cancel = nil
+}
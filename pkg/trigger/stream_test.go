@@ -0,0 +1,105 @@
+package trigger_test
+
+import (
+	"context"
+	"github.com/PxyUp/fitter/pkg/stream"
+	"github.com/PxyUp/fitter/pkg/trigger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeStream return queued messages and block until it is closed
+type fakeStream struct {
+	messages chan []byte
+	closed   chan struct{}
+}
+
+func newFakeStream(messages ...string) *fakeStream {
+	s := &fakeStream{
+		messages: make(chan []byte, len(messages)),
+		closed:   make(chan struct{}),
+	}
+	for _, msg := range messages {
+		s.messages <- []byte(msg)
+	}
+	return s
+}
+
+func (f *fakeStream) Next() ([]byte, error) {
+	select {
+	case msg := <-f.messages:
+		return msg, nil
+	case <-f.closed:
+		return nil, io.EOF
+	}
+}
+
+func (f *fakeStream) Close() error {
+	select {
+	case <-f.closed:
+	default:
+		close(f.closed)
+	}
+	return nil
+}
+
+func receive(t *testing.T, updates <-chan *trigger.Message) *trigger.Message {
+	select {
+	case msg := <-updates:
+		return msg
+	case <-time.After(3 * time.Second):
+		require.FailNow(t, "message is not received")
+		return nil
+	}
+}
+
+func TestStream(t *testing.T) {
+	var dials atomic.Int32
+	streams := []*fakeStream{
+		newFakeStream(`{"price": 1}`, "plain text"),
+		newFakeStream(`{"price": 2}`),
+	}
+
+	streamTrigger := trigger.Stream(context.Background(), "prices", func(ctx context.Context) (stream.Stream, error) {
+		s := streams[dials.Add(1)-1]
+		if dials.Load() == 1 {
+			// first connection is ended by server after messages
+			go func() {
+				for len(s.messages) > 0 {
+					time.Sleep(10 * time.Millisecond)
+				}
+				_ = s.Close()
+			}()
+		}
+		return s, nil
+	}, 1)
+
+	updates := make(chan *trigger.Message)
+	streamTrigger.Run(updates)
+
+	msg := receive(t, updates)
+	assert.Equal(t, "prices", msg.Name)
+	assert.JSONEq(t, `{"price": 1}`, msg.Value.ToJson())
+	assert.Equal(t, `"plain text"`, receive(t, updates).Value.ToJson())
+
+	assert.JSONEq(t, `{"price": 2}`, receive(t, updates).Value.ToJson())
+	assert.Equal(t, int32(2), dials.Load())
+
+	streamTrigger.Stop()
+	select {
+	case <-streams[1].closed:
+	case <-time.After(time.Second):
+		require.FailNow(t, "stream is not closed on stop")
+	}
+
+	select {
+	case msg = <-updates:
+		require.FailNow(t, "message is received after stop", msg.Name)
+	case <-time.After(1500 * time.Millisecond):
+	}
+	assert.Equal(t, int32(2), dials.Load())
+}
@@ -5,6 +5,8 @@ import (
 	"github.com/PxyUp/fitter/pkg/builder"
 	"github.com/PxyUp/fitter/pkg/config"
 	"github.com/PxyUp/fitter/pkg/logger"
+	"github.com/PxyUp/fitter/pkg/stream"
+	"github.com/PxyUp/fitter/pkg/utils"
 )
 
 type Message struct {
@@ -46,10 +48,42 @@ func createSchedulerTriggers(ctx context.Context, cfg *config.Config, logger log
 	return schedulers
 }
 
+func createStreamTriggers(ctx context.Context, cfg *config.Config, logger logger.Logger) []Trigger {
+	var triggers []Trigger
+	for _, item := range cfg.Items {
+		if item.TriggerConfig == nil {
+			continue
+		}
+
+		if wsCfg := item.TriggerConfig.WebSocketTrigger; wsCfg != nil {
+			triggers = append(triggers, Stream(ctx, item.Name, func(dialCtx context.Context) (stream.Stream, error) {
+				return stream.DialWebSocket(dialCtx, utils.Format(wsCfg.Url, nil, nil, nil), formatHeaders(wsCfg.Headers), utils.Format(wsCfg.Subscribe, nil, nil, nil))
+			}, wsCfg.Reconnect).WithLogger(logger.With("scheduler_name", item.Name, "scheduler_type", "websocket")))
+		}
+
+		if sseCfg := item.TriggerConfig.SSETrigger; sseCfg != nil {
+			triggers = append(triggers, Stream(ctx, item.Name, func(dialCtx context.Context) (stream.Stream, error) {
+				return stream.DialSSE(dialCtx, utils.Format(sseCfg.Url, nil, nil, nil), formatHeaders(sseCfg.Headers), sseCfg.Event)
+			}, sseCfg.Reconnect).WithLogger(logger.With("scheduler_name", item.Name, "scheduler_type", "sse")))
+		}
+	}
+
+	return triggers
+}
+
+func formatHeaders(headers map[string]string) map[string]string {
+	formatted := make(map[string]string, len(headers))
+	for k, v := range headers {
+		formatted[k] = utils.Format(v, nil, nil, nil)
+	}
+	return formatted
+}
+
 func CreateTriggers(ctx context.Context, cfg *config.Config, logger logger.Logger) []Trigger {
 	var triggers []Trigger
 	triggers = append(triggers, createHttpTrigger(ctx, cfg, logger)...)
 	triggers = append(triggers, createSchedulerTriggers(ctx, cfg, logger)...)
+	triggers = append(triggers, createStreamTriggers(ctx, cfg, logger)...)
 
 	return triggers
 }
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"github.com/PxyUp/fitter/pkg/builder"
 	"github.com/expr-lang/expr"
+	"strings"
 )
 
 const (
@@ -11,11 +12,20 @@ const (
 	fitterResultRef               = "fRes"
 	fitterIndexRef                = "fIndex"
 	fitterResultRaw               = "fResRaw"
+	fitterBaseURLRef              = "fBaseURL"
 	fitterNewLinePlaceholderKey   = "FNewLine"
 	fitterNewLinePlaceholderValue = "$__FLINE__$"
 )
 
 var (
+	// baseURLLiterals replace string literals with {BASE_URL} by variable, so url of the page is not spliced into expression
+	baseURLLiterals = strings.NewReplacer(
+		`"`+baseURLPlaceHolder+`"`, fitterBaseURLRef,
+		`'`+baseURLPlaceHolder+`'`, fitterBaseURLRef,
+		"`"+baseURLPlaceHolder+"`", fitterBaseURLRef,
+		baseURLPlaceHolder, fitterBaseURLRef,
+	)
+
 	defEnv = map[string]interface{}{
 		fitterNewLinePlaceholderKey: fitterNewLinePlaceholderValue,
 		"FNull":                     builder.NullValue,
@@ -23,6 +33,9 @@ var (
 		"isNull": func(value interface{}) bool {
 			return builder.NullValue == value
 		},
+		"resolveURL": func(base string, reference string) string {
+			return ResolveURL(base, reference)
+		},
 	}
 )
 
@@ -46,7 +59,14 @@ func extendEnv(env map[string]interface{}, result builder.Interfacable, index *u
 }
 
 func ProcessExpression(expression string, result builder.Interfacable, index *uint32, input builder.Interfacable) (builder.Interfacable, error) {
+	return ProcessExpressionWithBaseURL(expression, "", result, index, input)
+}
+
+// ProcessExpressionWithBaseURL process expression with url of the page in fBaseURL variable, {BASE_URL} in expression is replaced by this variable
+func ProcessExpressionWithBaseURL(expression string, baseURL string, result builder.Interfacable, index *uint32, input builder.Interfacable) (builder.Interfacable, error) {
 	env := extendEnv(defEnv, result, index)
+	env[fitterBaseURLRef] = baseURL
+	expression = baseURLLiterals.Replace(expression)
 	program, err := expr.Compile(Format(expression, result, index, input), expr.Env(env))
 	if err != nil {
 		return nil, err
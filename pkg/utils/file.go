@@ -3,6 +3,7 @@ package utils
 import (
 	"errors"
 	"github.com/PxyUp/fitter/pkg/logger"
+	"io"
 	"os"
 	"path"
 )
@@ -11,6 +12,54 @@ var (
 	ErrMissingFileName = errors.New("missing file name")
 )
 
+func createDir(destinationPath string, logger logger.Logger) error {
+	if _, errDir := os.Stat(destinationPath); os.IsNotExist(errDir) {
+		errCreationOfDir := os.MkdirAll(destinationPath, os.ModePerm)
+		if errCreationOfDir != nil {
+			logger.Errorw("unable to create directory", "path", destinationPath, "error", errCreationOfDir.Error())
+			return errCreationOfDir
+		}
+	}
+
+	return nil
+}
+
+// CreateFileFromReader stream content of reader into file without buffering it in memory, partial file is removed on error
+func CreateFileFromReader(reader io.Reader, destinationFileName string, destinationPath string, mode os.FileMode, limit int64, logger logger.Logger) (string, error) {
+	if destinationFileName == "" {
+		return "", ErrMissingFileName
+	}
+
+	localDest := path.Join(destinationPath, destinationFileName)
+	logger.Debugw("streaming file", "path", localDest)
+	if err := createDir(destinationPath, logger); err != nil {
+		return "", err
+	}
+
+	tmpDest := localDest + ".part"
+	file, err := os.OpenFile(tmpDest, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, mode)
+	if err != nil {
+		logger.Errorw("unable to open local file", "dest", tmpDest, "error", err.Error())
+		return "", err
+	}
+
+	_, err = io.Copy(LimitWriter(file, limit), reader)
+	errClose := file.Close()
+	if err == nil {
+		err = errClose
+	}
+	if err == nil {
+		err = os.Rename(tmpDest, localDest)
+	}
+	if err != nil {
+		_ = os.Remove(tmpDest)
+		logger.Errorw("unable to write content to local file", "dest", localDest, "error", err.Error())
+		return "", err
+	}
+
+	return localDest, nil
+}
+
 func CreateFileWithContent(content []byte, destinationFileName string, destinationPath string, mode os.FileMode, append bool, logger logger.Logger) (string, error) {
 	if destinationFileName == "" {
 		return "", ErrMissingFileName
@@ -18,12 +67,8 @@ func CreateFileWithContent(content []byte, destinationFileName string, destinati
 
 	localDest := path.Join(destinationPath, destinationFileName)
 	logger.Debugw("storing file", "path", localDest)
-	if _, errDir := os.Stat(destinationPath); os.IsNotExist(errDir) {
-		errCreationOfDir := os.MkdirAll(destinationPath, os.ModePerm)
-		if errCreationOfDir != nil {
-			logger.Errorw("unable to create directory", "path", destinationPath, "error", errCreationOfDir.Error())
-			return "", errCreationOfDir
-		}
+	if err := createDir(destinationPath, logger); err != nil {
+		return "", err
 	}
 
 	if append {
@@ -54,3 +99,48 @@ func CreateFileWithContent(content []byte, destinationFileName string, destinati
 
 	return localDest, nil
 }
+
+// WriteFileAtomic write content into temporary file in same directory and rename it, so readers never see partial file
+func WriteFileAtomic(content []byte, destinationFileName string, destinationPath string, mode os.FileMode, logger logger.Logger) (string, error) {
+	if destinationFileName == "" {
+		return "", ErrMissingFileName
+	}
+
+	localDest := path.Join(destinationPath, destinationFileName)
+	logger.Debugw("storing file atomically", "path", localDest)
+	if err := createDir(destinationPath, logger); err != nil {
+		return "", err
+	}
+
+	dir := destinationPath
+	if dir == "" {
+		dir = "."
+	}
+	file, err := os.CreateTemp(dir, destinationFileName+".*.tmp")
+	if err != nil {
+		logger.Errorw("unable to create temporary file", "dest", localDest, "error", err.Error())
+		return "", err
+	}
+
+	_, err = file.Write(content)
+	if err == nil {
+		err = file.Sync()
+	}
+	errClose := file.Close()
+	if err == nil {
+		err = errClose
+	}
+	if err == nil {
+		err = os.Chmod(file.Name(), mode)
+	}
+	if err == nil {
+		err = os.Rename(file.Name(), localDest)
+	}
+	if err != nil {
+		_ = os.Remove(file.Name())
+		logger.Errorw("unable to write content to local file", "dest", localDest, "error", err.Error())
+		return "", err
+	}
+
+	return localDest, nil
+}
@@ -8,7 +8,6 @@ import (
 	"github.com/PxyUp/fitter/pkg/references"
 	"github.com/tidwall/gjson"
 	"html"
-	"io"
 	"os"
 	"strings"
 )
@@ -19,6 +18,7 @@ const (
 	placeHolder           = "{PL}"
 	indexPlaceHolder      = "{INDEX}"
 	humanIndexPlaceHolder = "{HUMAN_INDEX}"
+	baseURLPlaceHolder    = "{BASE_URL}"
 	refNamePrefix         = "RefName="
 	envNamePrefix         = "FromEnv="
 	exprNamePrefix        = "FromExp="
@@ -55,6 +55,25 @@ func Format(str string, value builder.Interfacable, index *uint32, input builder
 	return strings.ReplaceAll(formatJsonPathString(str, value, index, input), fitterNewLinePlaceholderValue, "\n")
 }
 
+// IsPlaceholder check that str is exactly one placeholder without other text
+func IsPlaceholder(str string) bool {
+	switch str {
+	case placeHolder, indexPlaceHolder, humanIndexPlaceHolder:
+		return true
+	}
+
+	return strings.HasPrefix(str, jsonPathStart) && strings.HasSuffix(str, jsonPathEnd) && strings.Count(str, jsonPathStart) == 1
+}
+
+// FormatBaseURL inject url of the page(after redirects and with <base href>) instead of {BASE_URL}
+func FormatBaseURL(str string, baseURL string) string {
+	if !strings.Contains(str, baseURLPlaceHolder) {
+		return str
+	}
+
+	return strings.ReplaceAll(str, baseURLPlaceHolder, baseURL)
+}
+
 func processPrefix(prefix string, value builder.Interfacable, index *uint32, input builder.Interfacable) string {
 	if strings.HasPrefix(prefix, inputNamePrefix) {
 		path := strings.TrimPrefix(prefix, inputNamePrefix)
@@ -123,7 +142,7 @@ func processPrefix(prefix string, value builder.Interfacable, index *uint32, inp
 			defer resp.Body.Close()
 		}
 
-		content, err := io.ReadAll(resp.Body)
+		content, err := ReadAll(resp.Body, BodyLimit(0))
 		if err != nil {
 			formatterLogger.Errorw("cant read url response", "url_path", urlPath, "error", err.Error())
 			return builder.EMPTY.ToJson()
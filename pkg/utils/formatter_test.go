@@ -1,6 +1,7 @@
 package utils_test
 
 import (
+	"encoding/json"
 	"fmt"
 	"github.com/PxyUp/fitter/pkg/builder"
 	"github.com/PxyUp/fitter/pkg/config"
@@ -137,3 +138,17 @@ func (s *TestFormatterSuite) SetupSuite() {
 	})
 	os.Setenv("TEST_VAL", "test")
 }
+
+func (s *TestFormatterSuite) TestProcessExpressionWithBaseURL() {
+	baseURL := `http://example.com/a") + ("b\`
+
+	res, err := utils.ProcessExpressionWithBaseURL(`fBaseURL + '|' + "{BASE_URL}"`, baseURL, builder.String("item"), nil, nil)
+	s.Require().NoError(err)
+	var value string
+	s.Require().NoError(json.Unmarshal(res.Raw(), &value))
+	assert.Equal(s.T(), baseURL+"|"+baseURL, value)
+
+	res, err = utils.ProcessExpressionWithBaseURL(`resolveURL("{BASE_URL}", fRes)`, "http://example.com/shop/", builder.String("item/1"), nil, nil)
+	s.Require().NoError(err)
+	assert.JSONEq(s.T(), `"http://example.com/shop/item/1"`, string(res.Raw()))
+}
@@ -0,0 +1,68 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"github.com/PxyUp/fitter/pkg/limitter"
+	"io"
+)
+
+var (
+	ErrBodyTooLarge = errors.New("body too large")
+)
+
+// BodyLimit return limit of the body size in bytes, limit of connector has priority over global one
+func BodyLimit(connectorLimit int64) int64 {
+	if connectorLimit > 0 {
+		return connectorLimit
+	}
+
+	return limitter.MaxBodySize()
+}
+
+// ReadAll read whole reader but not more than limit bytes, limit <= 0 means without limit
+func ReadAll(reader io.Reader, limit int64) ([]byte, error) {
+	if limit <= 0 {
+		return io.ReadAll(reader)
+	}
+
+	content, err := io.ReadAll(io.LimitReader(reader, limit+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if int64(len(content)) > limit {
+		return nil, fmt.Errorf("%w: limit is %d bytes", ErrBodyTooLarge, limit)
+	}
+
+	return content, nil
+}
+
+// LimitWriter fail with ErrBodyTooLarge when more than limit bytes written, limit <= 0 means without limit
+func LimitWriter(writer io.Writer, limit int64) io.Writer {
+	if limit <= 0 {
+		return writer
+	}
+
+	return &limitWriter{
+		writer: writer,
+		left:   limit,
+		limit:  limit,
+	}
+}
+
+type limitWriter struct {
+	writer io.Writer
+	left   int64
+	limit  int64
+}
+
+func (l *limitWriter) Write(p []byte) (int, error) {
+	if int64(len(p)) > l.left {
+		return 0, fmt.Errorf("%w: limit is %d bytes", ErrBodyTooLarge, l.limit)
+	}
+
+	n, err := l.writer.Write(p)
+	l.left -= int64(n)
+	return n, err
+}
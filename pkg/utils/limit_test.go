@@ -0,0 +1,43 @@
+package utils_test
+
+import (
+	"bytes"
+	"errors"
+	"github.com/PxyUp/fitter/pkg/logger"
+	"github.com/PxyUp/fitter/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path"
+	"strings"
+	"testing"
+)
+
+func TestReadAll(t *testing.T) {
+	content, err := utils.ReadAll(strings.NewReader("12345"), 5)
+	require.NoError(t, err)
+	assert.Equal(t, "12345", string(content))
+
+	_, err = utils.ReadAll(strings.NewReader("123456"), 5)
+	assert.True(t, errors.Is(err, utils.ErrBodyTooLarge))
+
+	content, err = utils.ReadAll(strings.NewReader("123456"), 0)
+	require.NoError(t, err)
+	assert.Equal(t, "123456", string(content))
+}
+
+func TestCreateFileFromReader(t *testing.T) {
+	dir := path.Join(t.TempDir(), "nested")
+
+	filePath, err := utils.CreateFileFromReader(bytes.NewReader([]byte("content")), "file.txt", dir, 0600, 7, logger.Null)
+	require.NoError(t, err)
+	content, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, "content", string(content))
+
+	_, err = utils.CreateFileFromReader(bytes.NewReader([]byte("too big content")), "big.txt", dir, 0600, 7, logger.Null)
+	assert.True(t, errors.Is(err, utils.ErrBodyTooLarge))
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
@@ -0,0 +1,252 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/PxyUp/fitter/pkg/config"
+	"github.com/tidwall/gjson"
+	htmlTemplate "html/template"
+	"strconv"
+	"strings"
+	textTemplate "text/template"
+	"time"
+	"unicode/utf8"
+)
+
+var (
+	errUnknownTemplateMode = errors.New("unknown template mode")
+
+	markdownEscaper = strings.NewReplacer(
+		"_", "\\_", "*", "\\*", "[", "\\[", "]", "\\]", "(", "\\(", ")", "\\)", "~", "\\~", "`", "\\`", ">", "\\>",
+		"#", "\\#", "+", "\\+", "-", "\\-", "=", "\\=", "|", "\\|", "{", "\\{", "}", "\\}", ".", "\\.", "!", "\\!", "\\", "\\\\",
+	)
+
+	templateFuncs = map[string]interface{}{
+		"join":     templateJoin,
+		"truncate": templateTruncate,
+		"date":     templateDate,
+		"now":      time.Now,
+		"json":     templateJson,
+		"path":     templatePath,
+		"default":  templateDefault,
+		"add":      templateAdd,
+		"upper":    strings.ToUpper,
+		"lower":    strings.ToLower,
+		"trim":     strings.TrimSpace,
+		"replace":  templateReplace,
+		"split":    templateSplit,
+		"markdown": markdownEscaper.Replace,
+	}
+)
+
+// TemplateData is value of the dot inside notifier template
+type TemplateData struct {
+	// Result decoded result, numbers are json.Number
+	Result interface{}
+	// Raw json of the result
+	Raw  string
+	Name string
+	// Index of the item for array result sent by item, nil otherwise
+	Index interface{}
+	Input interface{}
+	// Error message, empty if result is successful
+	Error string
+}
+
+type Template struct {
+	execute func(buf *bytes.Buffer, data *TemplateData) error
+}
+
+// NewTemplate parse text/template or html/template with helper functions
+func NewTemplate(name string, text string, mode config.TemplateMode) (*Template, error) {
+	switch mode {
+	case config.TextTemplate:
+		tmpl, err := textTemplate.New(name).Funcs(templateFuncs).Parse(text)
+		if err != nil {
+			return nil, err
+		}
+		return &Template{
+			execute: func(buf *bytes.Buffer, data *TemplateData) error {
+				return tmpl.Execute(buf, data)
+			},
+		}, nil
+	case config.HTMLTemplate:
+		tmpl, err := htmlTemplate.New(name).Funcs(templateFuncs).Parse(text)
+		if err != nil {
+			return nil, err
+		}
+		return &Template{
+			execute: func(buf *bytes.Buffer, data *TemplateData) error {
+				return tmpl.Execute(buf, data)
+			},
+		}, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s", errUnknownTemplateMode, mode)
+}
+
+func (t *Template) Execute(data *TemplateData) (string, error) {
+	var buf bytes.Buffer
+	if err := t.execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// DecodeJson decode json with json.Number for numbers, so big integers are not printed in exponent form
+func DecodeJson(raw []byte) (interface{}, error) {
+	var value interface{}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+func templateJoin(sep string, list interface{}) string {
+	switch value := list.(type) {
+	case []string:
+		return strings.Join(value, sep)
+	case []interface{}:
+		parts := make([]string, len(value))
+		for i, item := range value {
+			parts[i] = templateString(item)
+		}
+		return strings.Join(parts, sep)
+	}
+	return templateString(list)
+}
+
+func templateTruncate(length int, value interface{}) string {
+	str := templateString(value)
+	if length <= 0 || utf8.RuneCountInString(str) <= length {
+		return str
+	}
+	return string([]rune(str)[:length]) + "…"
+}
+
+// templateDate format time, unix timestamp(seconds) or RFC3339 string with Go layout
+func templateDate(layout string, value interface{}) string {
+	switch v := value.(type) {
+	case time.Time:
+		return v.Format(layout)
+	case json.Number:
+		if unix, err := v.Int64(); err == nil {
+			return time.Unix(unix, 0).UTC().Format(layout)
+		}
+		if unix, err := v.Float64(); err == nil {
+			return time.Unix(int64(unix), 0).UTC().Format(layout)
+		}
+	case float64:
+		return time.Unix(int64(v), 0).UTC().Format(layout)
+	case int:
+		return time.Unix(int64(v), 0).UTC().Format(layout)
+	case int64:
+		return time.Unix(v, 0).UTC().Format(layout)
+	case string:
+		if parsed, err := time.Parse(time.RFC3339, v); err == nil {
+			return parsed.Format(layout)
+		}
+		if unix, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return time.Unix(unix, 0).UTC().Format(layout)
+		}
+	}
+	return templateString(value)
+}
+
+func templateJson(value interface{}) string {
+	bb, err := json.Marshal(value)
+	if err != nil {
+		return ""
+	}
+	return string(bb)
+}
+
+// templatePath return value by json path, see https://github.com/tidwall/gjson/blob/master/SYNTAX.md
+func templatePath(path string, value interface{}) interface{} {
+	raw := []byte(templateJson(value))
+	if str, ok := value.(string); ok && gjson.Valid(str) {
+		raw = []byte(str)
+	}
+
+	result := gjson.GetBytes(raw, path)
+	if !result.Exists() {
+		return nil
+	}
+	decoded, err := DecodeJson([]byte(result.Raw))
+	if err != nil {
+		return result.String()
+	}
+	return decoded
+}
+
+func templateDefault(def interface{}, value interface{}) interface{} {
+	if value == nil || value == "" {
+		return def
+	}
+	return value
+}
+
+func templateAdd(a interface{}, b interface{}) interface{} {
+	aInt, aIsInt := templateInt(a)
+	bInt, bIsInt := templateInt(b)
+	if aIsInt && bIsInt {
+		return aInt + bInt
+	}
+	return templateFloat(a) + templateFloat(b)
+}
+
+func templateReplace(old string, new string, value interface{}) string {
+	return strings.ReplaceAll(templateString(value), old, new)
+}
+
+func templateSplit(sep string, value interface{}) []string {
+	return strings.Split(templateString(value), sep)
+}
+
+func templateInt(value interface{}) (int64, bool) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), true
+	case int64:
+		return v, true
+	case uint32:
+		return int64(v), true
+	case json.Number:
+		i, err := v.Int64()
+		return i, err == nil
+	}
+	return 0, false
+}
+
+func templateFloat(value interface{}) float64 {
+	switch v := value.(type) {
+	case float64:
+		return v
+	case json.Number:
+		f, _ := v.Float64()
+		return f
+	case string:
+		f, _ := strconv.ParseFloat(v, 64)
+		return f
+	}
+	if i, ok := templateInt(value); ok {
+		return float64(i)
+	}
+	return 0
+}
+
+func templateString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case map[string]interface{}, []interface{}:
+		return templateJson(v)
+	}
+	return fmt.Sprint(value)
+}
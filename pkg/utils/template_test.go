@@ -0,0 +1,49 @@
+package utils_test
+
+import (
+	"github.com/PxyUp/fitter/pkg/config"
+	"github.com/PxyUp/fitter/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestTemplate(t *testing.T) {
+	result, err := utils.DecodeJson([]byte(`{
+		"items": [
+			{"title": "Go 2 released", "stars": 1000000, "tags": ["go", "release"], "date": 1700000000},
+			{"title": "Very long title of the second item", "stars": 5, "tags": [], "date": "2024-01-02T10:00:00Z"}
+		]
+	}`))
+	require.NoError(t, err)
+
+	tmpl, err := utils.NewTemplate("digest", `{{.Name}}:
+{{range $i, $item := .Result.items}}{{add $i 1}}. {{truncate 10 $item.title}} ({{$item.stars}}) [{{join ", " $item.tags | default "-"}}] {{date "2006-01-02" $item.date}}
+{{end}}first={{path "items.0.title" .Result | upper}}`, config.TextTemplate)
+	require.NoError(t, err)
+
+	text, err := tmpl.Execute(&utils.TemplateData{
+		Name:   "news",
+		Result: result,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, `news:
+1. Go 2 relea… (1000000) [go, release] 2023-11-14
+2. Very long … (5) [-] 2024-01-02
+first=GO 2 RELEASED`, text)
+
+	htmlTmpl, err := utils.NewTemplate("html", `<b>{{.Result}}</b>{{if .Error}} error: {{.Error}}{{end}}`, config.HTMLTemplate)
+	require.NoError(t, err)
+
+	text, err = htmlTmpl.Execute(&utils.TemplateData{
+		Result: "<script>",
+		Error:  "timeout",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, `<b>&lt;script&gt;</b> error: timeout`, text)
+
+	_, err = utils.NewTemplate("invalid", `{{.Result`, config.TextTemplate)
+	assert.Error(t, err)
+	_, err = utils.NewTemplate("mode", `{{.Result}}`, "jinja")
+	assert.Error(t, err)
+}
@@ -0,0 +1,26 @@
+package utils
+
+import (
+	"net/url"
+	"strings"
+)
+
+// ResolveURL resolve reference against base url, reference returned as is if it can not be resolved
+func ResolveURL(base string, reference string) string {
+	reference = strings.TrimSpace(reference)
+	if base == "" || reference == "" {
+		return reference
+	}
+
+	baseURL, err := url.Parse(strings.TrimSpace(base))
+	if err != nil {
+		return reference
+	}
+
+	referenceURL, err := url.Parse(reference)
+	if err != nil {
+		return reference
+	}
+
+	return baseURL.ResolveReference(referenceURL).String()
+}